@@ -0,0 +1,101 @@
+package gosmpp
+
+import (
+	"errors"
+	"math"
+	"math/rand/v2"
+	"time"
+
+	"github.com/linxGnu/gosmpp/data"
+)
+
+// NonRetryableBindStatuses are bind_resp command statuses on which Session with a BackoffPolicy
+// gives up rebinding, since retrying with the same credentials would never succeed.
+var NonRetryableBindStatuses = []data.CommandStatusType{
+	data.ESME_RINVPASWD,
+	data.ESME_RINVSYSID,
+	data.ESME_RINVSYSTYP,
+}
+
+// BackoffPolicy decides waiting duration between rebinding attempts of Session.
+type BackoffPolicy interface {
+	// Next returns waiting duration after the given failed attempt (starting from 1),
+	// given elapsed duration since rebinding started.
+	//
+	// Returning false gives up rebinding.
+	Next(attempt int, elapsed time.Duration) (time.Duration, bool)
+}
+
+// ExponentialBackoff is a BackoffPolicy whose waiting duration grows exponentially,
+// capped and randomly jittered.
+type ExponentialBackoff struct {
+	// InitialInterval is the waiting duration after the first failed attempt.
+	InitialInterval time.Duration
+
+	// MaxInterval caps the waiting duration between attempts.
+	//
+	// Zero duration means no cap.
+	MaxInterval time.Duration
+
+	// Multiplier is the factor by which waiting duration grows after each attempt.
+	//
+	// Value less than 1 is treated as 2.
+	Multiplier float64
+
+	// Jitter randomly reduces waiting duration by up to this fraction, in range [0, 1].
+	Jitter float64
+
+	// MaxAttempts is the maximum number of rebinding attempts.
+	//
+	// Zero value means no limit.
+	MaxAttempts int
+
+	// MaxElapsedTime is the maximum duration of rebinding, after which Session gives up.
+	//
+	// Zero duration means no limit.
+	MaxElapsedTime time.Duration
+}
+
+// Next implements BackoffPolicy.
+func (b *ExponentialBackoff) Next(attempt int, elapsed time.Duration) (time.Duration, bool) {
+	if b.MaxAttempts > 0 && attempt >= b.MaxAttempts {
+		return 0, false
+	}
+
+	backoff := exponentialBackoff(b.InitialInterval, b.MaxInterval, b.Multiplier, b.Jitter, attempt-1)
+	if b.MaxElapsedTime > 0 && elapsed+backoff > b.MaxElapsedTime {
+		return 0, false
+	}
+	return backoff, true
+}
+
+// exponentialBackoff returns waiting duration before the given retry (starting from 0).
+func exponentialBackoff(initial, maximum time.Duration, multiplier, jitter float64, retry int) time.Duration {
+	if multiplier < 1 {
+		multiplier = 2
+	}
+
+	backoff := float64(initial) * math.Pow(multiplier, float64(retry))
+	if maximum > 0 && backoff > float64(maximum) {
+		backoff = float64(maximum)
+	}
+
+	if jitter = math.Min(math.Max(jitter, 0), 1); jitter > 0 {
+		backoff -= backoff * jitter * rand.Float64()
+	}
+
+	return time.Duration(backoff)
+}
+
+// IsRetryableBindError checks if rebinding should be retried after error of Connector.
+func IsRetryableBindError(err error) bool {
+	var bindErr BindError
+	if errors.As(err, &bindErr) {
+		for _, status := range NonRetryableBindStatuses {
+			if bindErr.CommandStatus == status {
+				return false
+			}
+		}
+	}
+	return true
+}
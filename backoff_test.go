@@ -0,0 +1,154 @@
+package gosmpp
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/linxGnu/gosmpp/data"
+	"github.com/linxGnu/gosmpp/pdu"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestExponentialBackoff(t *testing.T) {
+	policy := &ExponentialBackoff{
+		InitialInterval: 100 * time.Millisecond,
+		MaxInterval:     time.Second,
+	}
+
+	for attempt, expected := range map[int]time.Duration{
+		1:  100 * time.Millisecond,
+		2:  200 * time.Millisecond,
+		4:  800 * time.Millisecond,
+		5:  time.Second,
+		50: time.Second,
+	} {
+		backoff, ok := policy.Next(attempt, 0)
+		require.True(t, ok)
+		require.Equal(t, expected, backoff)
+	}
+
+	policy.Jitter = 0.5
+	for i := 0; i < 100; i++ {
+		backoff, _ := policy.Next(2, 0)
+		require.GreaterOrEqual(t, backoff, 100*time.Millisecond)
+		require.LessOrEqual(t, backoff, 200*time.Millisecond)
+	}
+	policy.Jitter = 0
+
+	policy.MaxAttempts = 3
+	_, ok := policy.Next(2, 0)
+	require.True(t, ok)
+	_, ok = policy.Next(3, 0)
+	require.False(t, ok)
+
+	policy.MaxElapsedTime = time.Second
+	_, ok = policy.Next(1, 900*time.Millisecond)
+	require.True(t, ok)
+	_, ok = policy.Next(1, 901*time.Millisecond)
+	require.False(t, ok)
+}
+
+func TestIsRetryableBindError(t *testing.T) {
+	require.True(t, IsRetryableBindError(errors.New("connection refused")))
+	require.True(t, IsRetryableBindError(BindError{CommandStatus: data.ESME_RBINDFAIL}))
+	require.False(t, IsRetryableBindError(BindError{CommandStatus: data.ESME_RINVPASWD}))
+	require.False(t, IsRetryableBindError(BindError{CommandStatus: data.ESME_RINVSYSID}))
+}
+
+func TestSessionRebindGiveUp(t *testing.T) {
+	t.Run("NonRetryable", func(t *testing.T) {
+		var rejected int32
+
+		server, addr := newTestServer(t, ServerSettings{
+			Authenticate: func(req *pdu.BindRequest) data.CommandStatusType {
+				if atomic.LoadInt32(&rejected) == 1 {
+					return data.ESME_RINVPASWD
+				}
+				return data.ESME_ROK
+			},
+			SessionSettings: func(*ServerSession) Settings {
+				return Settings{ReadTimeout: 2 * time.Second}
+			},
+		})
+		defer func() {
+			_ = server.Close()
+		}()
+
+		var rebindingErrors int32
+		gaveUp := make(chan error, 1)
+
+		session, err := NewSession(
+			TRXConnector(NonTLSDialer, Auth{SMSC: addr, SystemID: "esme", Password: "secret"}),
+			Settings{
+				ReadTimeout: 2 * time.Second,
+				OnRebindingError: func(error) {
+					atomic.AddInt32(&rebindingErrors, 1)
+				},
+				OnRebindingGiveUp: func(err error) {
+					gaveUp <- err
+				},
+			}, 10*time.Millisecond,
+			WithBackoffPolicy(&ExponentialBackoff{InitialInterval: 10 * time.Millisecond}))
+		require.Nil(t, err)
+
+		require.Eventually(t, func() bool {
+			return len(server.Sessions()) == 1
+		}, time.Second, 10*time.Millisecond)
+
+		// password got revoked
+		atomic.StoreInt32(&rejected, 1)
+		require.Nil(t, server.Sessions()[0].Close())
+
+		select {
+		case err = <-gaveUp:
+			require.Equal(t, BindError{CommandStatus: data.ESME_RINVPASWD}, err)
+		case <-time.After(2 * time.Second):
+			t.Fatal("session did not give up")
+		}
+		require.EqualValues(t, 1, atomic.LoadInt32(&rebindingErrors))
+		require.Nil(t, session.Close())
+	})
+
+	t.Run("MaxAttempts", func(t *testing.T) {
+		server, addr := newTestServer(t, ServerSettings{
+			SessionSettings: func(*ServerSession) Settings {
+				return Settings{ReadTimeout: 2 * time.Second}
+			},
+		})
+
+		var rebindingErrors int32
+		gaveUp := make(chan error, 1)
+
+		_, err := NewSession(
+			TRXConnector(NonTLSDialer, Auth{SMSC: addr, SystemID: "esme", Password: "secret"}),
+			Settings{
+				ReadTimeout: 2 * time.Second,
+				OnRebindingError: func(error) {
+					atomic.AddInt32(&rebindingErrors, 1)
+				},
+				OnRebindingGiveUp: func(err error) {
+					gaveUp <- err
+				},
+			}, 10*time.Millisecond,
+			WithBackoffPolicy(&ExponentialBackoff{InitialInterval: 10 * time.Millisecond, MaxAttempts: 3}))
+		require.Nil(t, err)
+
+		require.Eventually(t, func() bool {
+			return len(server.Sessions()) == 1
+		}, time.Second, 10*time.Millisecond)
+
+		// SMSC goes down
+		require.Nil(t, server.Close())
+
+		select {
+		case err = <-gaveUp:
+			require.NotNil(t, err)
+		case <-time.After(2 * time.Second):
+			t.Fatal("session did not give up")
+		}
+		require.EqualValues(t, 3, atomic.LoadInt32(&rebindingErrors))
+	})
+}
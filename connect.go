@@ -35,12 +35,55 @@ func (err BindError) Error() string {
 	return fmt.Sprintf("binding error (%s): %s", err.CommandStatus, err.CommandStatus.Desc())
 }
 
-func newBindRequest(s Auth, bindingType pdu.BindingType, addressRange pdu.AddressRange) (bindReq *pdu.BindRequest) {
+// interfaceVersion33 is interface version of SMPP 3.3, which does not support optional parameters.
+const interfaceVersion33 = byte(0x33)
+
+// Capabilities represents features supported within a bind, according to negotiated interface version.
+type Capabilities struct {
+	// InterfaceVersion is negotiated interface version, i.e. lower one of interface_version
+	// sent within bind request and sc_interface_version returned by SMSC.
+	//
+	// SMSC which does not return sc_interface_version is treated as SMPP 3.3 one.
+	InterfaceVersion byte
+
+	// OptionalParameters indicates that SMSC supports optional parameters (SMPP 3.4 and later).
+	OptionalParameters bool
+
+	// Broadcast indicates that SMSC supports broadcast_sm, query_broadcast_sm and cancel_broadcast_sm.
+	Broadcast bool
+
+	// CongestionState indicates that SMSC could report its load within congestion_state TLV.
+	CongestionState bool
+}
+
+func newCapabilities(interfaceVersion byte) Capabilities {
+	return Capabilities{
+		InterfaceVersion:   interfaceVersion,
+		OptionalParameters: interfaceVersion >= data.SMPP_V34,
+		Broadcast:          interfaceVersion >= data.SMPP_V50,
+		CongestionState:    interfaceVersion >= data.SMPP_V50,
+	}
+}
+
+// negotiateInterfaceVersion returns interface version of bind, given requested version and
+// sc_interface_version TLV of bind response.
+func negotiateInterfaceVersion(requested byte, resp *pdu.BindResp) byte {
+	version, err := resp.GetTLVUint8(pdu.TagScInterfaceVersion)
+	if err != nil {
+		return interfaceVersion33
+	}
+	return min(requested, version)
+}
+
+func newBindRequest(s Auth, bindingType pdu.BindingType, addressRange pdu.AddressRange, interfaceVersion byte) (bindReq *pdu.BindRequest) {
 	bindReq = pdu.NewBindRequest(bindingType)
 	bindReq.SystemID = s.SystemID
 	bindReq.Password = s.Password
 	bindReq.SystemType = s.SystemType
 	bindReq.AddressRange = addressRange
+	if interfaceVersion != 0 {
+		bindReq.InterfaceVersion = interfaceVersion
+	}
 	return
 }
 
@@ -55,6 +98,9 @@ type connector struct {
 	auth         Auth
 	bindingType  pdu.BindingType
 	addressRange pdu.AddressRange
+	sequence     SequenceGenerator
+
+	interfaceVersion byte
 }
 
 func (c *connector) GetBindType() pdu.BindingType {
@@ -62,23 +108,56 @@ func (c *connector) GetBindType() pdu.BindingType {
 }
 
 func (c *connector) Connect() (conn *Connection, err error) {
-	conn, err = connect(c.dialer, c.auth.SMSC, newBindRequest(c.auth, c.bindingType, c.addressRange))
+	return c.connect(nil)
+}
+
+func (c *connector) connect(onBinding func()) (conn *Connection, err error) {
+	conn, err = connect(c.dialer, c.auth.SMSC, newBindRequest(c.auth, c.bindingType, c.addressRange, c.interfaceVersion), c.sequence, onBinding)
 	return
 }
 
-func connect(dialer Dialer, addr string, bindReq *pdu.BindRequest) (c *Connection, err error) {
+// stagedConnector is implemented by connectors which report progress of Connect,
+// i.e. once network connection is established and bind request is about to be sent.
+type stagedConnector interface {
+	connect(onBinding func()) (*Connection, error)
+}
+
+func connect(dialer Dialer, addr string, bindReq *pdu.BindRequest, sequence SequenceGenerator, onBinding func()) (c *Connection, err error) {
 	conn, err := dialer(addr)
 	if err != nil {
 		return
 	}
 
+	if onBinding != nil {
+		onBinding()
+	}
+
 	// create wrapped connection
 	c = NewConnection(conn)
+	c.endpoint = addr
+
+	err = bind(c, bindReq, sequence)
+	return
+}
+
+// bind sends bind request over connection and waits for its response.
+// Connection is closed if bind fails.
+func bind(c *Connection, bindReq *pdu.BindRequest, sequence SequenceGenerator) (err error) {
+	if sequence != nil {
+		c.SetSequenceGenerator(sequence)
+	}
 
 	// send binding request
+	var seq int32
+	if seq, err = c.NextSequenceNumber(); err != nil {
+		_ = c.Close()
+		return
+	}
+	bindReq.SetSequenceNumber(seq)
+
 	_, err = c.WritePDU(bindReq)
 	if err != nil {
-		_ = conn.Close()
+		_ = c.Close()
 		return
 	}
 
@@ -90,7 +169,7 @@ func connect(dialer Dialer, addr string, bindReq *pdu.BindRequest) (c *Connectio
 
 	for {
 		if p, err = pdu.Parse(c); err != nil {
-			_ = conn.Close()
+			_ = c.Close()
 			return
 		}
 
@@ -102,21 +181,26 @@ func connect(dialer Dialer, addr string, bindReq *pdu.BindRequest) (c *Connectio
 
 	if resp.CommandStatus != data.ESME_ROK {
 		err = BindError{CommandStatus: resp.CommandStatus}
-		_ = conn.Close()
+		_ = c.Close()
 	} else {
 		c.systemID = resp.SystemID
+		c.interfaceVersion = negotiateInterfaceVersion(bindReq.InterfaceVersion, resp)
 	}
 
 	return
 }
 
 // TXConnector returns a Transmitter (TX) connector.
-func TXConnector(dialer Dialer, auth Auth) Connector {
-	return &connector{
+func TXConnector(dialer Dialer, auth Auth, opts ...connectorOption) Connector {
+	c := &connector{
 		dialer:      dialer,
 		auth:        auth,
 		bindingType: pdu.Transmitter,
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
 // RXConnector returns a Receiver (RX) connector.
@@ -152,3 +236,20 @@ func WithAddressRange(addressRange pdu.AddressRange) connectorOption {
 		c.addressRange = addressRange
 	}
 }
+
+// WithSequenceGenerator shares given sequence generator between connections,
+// instead of starting from 1 for each bind, i.e. to keep sequence numbers unique
+// across rebinds or restarts.
+func WithSequenceGenerator(g SequenceGenerator) connectorOption {
+	return func(c *connector) {
+		c.sequence = g
+	}
+}
+
+// WithInterfaceVersion sets interface_version of bind request, e.g. data.SMPP_V50
+// to negotiate SMPP 5.0. Default is data.SMPP_V34.
+func WithInterfaceVersion(version byte) connectorOption {
+	return func(c *connector) {
+		c.interfaceVersion = version
+	}
+}
@@ -11,19 +11,42 @@ import (
 // Connection wraps over net.Conn with buffered data reader.
 type Connection struct {
 	systemID string
+
+	// interfaceVersion is negotiated interface version of bind
+	interfaceVersion byte
+
+	// endpoint is SMSC address dialed for bind
+	endpoint string
+
 	conn     net.Conn
 	reader   *bufio.Reader
+	sequence SequenceGenerator
 }
 
 // NewConnection returns a Connection.
+//
+// Connection has its own in-memory sequence generator, starting from 1.
 func NewConnection(conn net.Conn) (c *Connection) {
 	c = &Connection{
-		conn:   conn,
-		reader: bufio.NewReaderSize(conn, 128<<10),
+		conn:     conn,
+		reader:   bufio.NewReaderSize(conn, 128<<10),
+		sequence: NewSequenceGenerator(),
 	}
 	return
 }
 
+// SetSequenceGenerator replaces sequence generator of the connection.
+//
+// Must be called before any PDU is written.
+func (c *Connection) SetSequenceGenerator(g SequenceGenerator) {
+	c.sequence = g
+}
+
+// NextSequenceNumber returns next sequence number from the connection's sequence generator.
+func (c *Connection) NextSequenceNumber() (int32, error) {
+	return c.sequence.Next()
+}
+
 // Read reads data from the connection.
 // Read can be made to time out and return an Error with Timeout() == true
 // after a fixed time limit; see SetDeadline and SetReadDeadline.
@@ -176,8 +176,9 @@ func unpack(src []byte, packed bool) (septets []byte) {
 				septets = append(septets, (src[count+4]&0x07<<4)|(src[count+3]&0xF0>>4))
 				septets = append(septets, (src[count+5]&0x03<<5)|(src[count+4]&0xF8>>3))
 				septets = append(septets, (src[count+6]&0x01<<6)|(src[count+5]&0xFC>>2))
-				if src[count+6] > 0 {
-					septets = append(septets, src[count+6]&0xFE>>1)
+				// the 8th septet is absent if 7 spare bits of the last octet are zero or CR padding
+				if last := src[count+6] & 0xFE >> 1; last > 0 && (last != 0x0D || len(src) > count+7) {
+					septets = append(septets, last)
 				}
 				count += 7
 			case remain >= 6:
@@ -0,0 +1,161 @@
+package data
+
+import (
+	"errors"
+	"strings"
+
+	"golang.org/x/text/encoding/japanese"
+	"golang.org/x/text/encoding/korean"
+)
+
+// jis encodes JIS X 0208 character set in its common Shift_JIS form.
+type jis struct{}
+
+func (*jis) Encode(str string) ([]byte, error) {
+	return encode(str, japanese.ShiftJIS.NewEncoder())
+}
+
+func (*jis) Decode(data []byte) (string, error) {
+	return decode(data, japanese.ShiftJIS.NewDecoder())
+}
+
+func (*jis) DataCoding() byte { return JISCoding }
+
+func (c *jis) ShouldSplit(text string, octetLimit uint) bool {
+	return shouldSplitEncoded(c, text, octetLimit)
+}
+
+func (c *jis) EncodeSplit(text string, octetLimit uint) ([][]byte, error) {
+	return encodeSplitMultibyte(c, text, octetLimit)
+}
+
+type iso2022jp struct{}
+
+func (*iso2022jp) Encode(str string) ([]byte, error) {
+	return encode(str, japanese.ISO2022JP.NewEncoder())
+}
+
+func (*iso2022jp) Decode(data []byte) (string, error) {
+	return decode(data, japanese.ISO2022JP.NewDecoder())
+}
+
+func (*iso2022jp) DataCoding() byte { return ISO2022JPCoding }
+
+func (c *iso2022jp) ShouldSplit(text string, octetLimit uint) bool {
+	return shouldSplitEncoded(c, text, octetLimit)
+}
+
+func (c *iso2022jp) EncodeSplit(text string, octetLimit uint) ([][]byte, error) {
+	return encodeSplitMultibyte(c, text, octetLimit)
+}
+
+// extendedKanjiJIS encodes JIS X 0212 supplementary kanji, along with JIS X 0208, in EUC-JP form.
+type extendedKanjiJIS struct{}
+
+func (*extendedKanjiJIS) Encode(str string) ([]byte, error) {
+	return encode(str, japanese.EUCJP.NewEncoder())
+}
+
+func (*extendedKanjiJIS) Decode(data []byte) (string, error) {
+	return decode(data, japanese.EUCJP.NewDecoder())
+}
+
+func (*extendedKanjiJIS) DataCoding() byte { return EXTKANJICoding }
+
+func (c *extendedKanjiJIS) ShouldSplit(text string, octetLimit uint) bool {
+	return shouldSplitEncoded(c, text, octetLimit)
+}
+
+func (c *extendedKanjiJIS) EncodeSplit(text string, octetLimit uint) ([][]byte, error) {
+	return encodeSplitMultibyte(c, text, octetLimit)
+}
+
+// ksc5601 encodes KS C 5601 (KS X 1001) character set in EUC-KR form.
+type ksc5601 struct{}
+
+func (*ksc5601) Encode(str string) ([]byte, error) {
+	return encode(str, korean.EUCKR.NewEncoder())
+}
+
+func (*ksc5601) Decode(data []byte) (string, error) {
+	return decode(data, korean.EUCKR.NewDecoder())
+}
+
+func (*ksc5601) DataCoding() byte { return KSC5601Coding }
+
+func (c *ksc5601) ShouldSplit(text string, octetLimit uint) bool {
+	return shouldSplitEncoded(c, text, octetLimit)
+}
+
+func (c *ksc5601) EncodeSplit(text string, octetLimit uint) ([][]byte, error) {
+	return encodeSplitMultibyte(c, text, octetLimit)
+}
+
+func shouldSplitEncoded(enc EncDec, text string, octetLimit uint) bool {
+	encoded, err := enc.Encode(text)
+	return err != nil || uint(len(encoded)) > octetLimit
+}
+
+// ErrClusterTooLong means a grapheme cluster is encoded into more octets than fit in a segment.
+var ErrClusterTooLong = errors.New("grapheme cluster does not fit in a segment")
+
+// encodeSplitMultibyte splits text at grapheme cluster boundaries, so that no multibyte character is cut.
+//
+// Each segment is encoded on its own, thus stateful encodings like ISO-2022-JP produce
+// self-contained segments, starting and ending in ASCII mode.
+func encodeSplitMultibyte(enc EncDec, text string, octetLimit uint) (allSeg [][]byte, err error) {
+	if octetLimit < 64 {
+		octetLimit = 134
+	}
+
+	allSeg = [][]byte{}
+
+	var (
+		part strings.Builder
+		size uint // encoded size of part, or its upper bound for stateful encodings
+	)
+	for _, cluster := range graphemeClusters(text) {
+		encoded, err := enc.Encode(cluster)
+		if err != nil {
+			return nil, err
+		}
+
+		n := uint(len(encoded))
+		if n > octetLimit {
+			return nil, ErrClusterTooLong
+		}
+
+		if part.Len() > 0 && size+n > octetLimit {
+			// clusters encoded on their own could sum up to more than the joined text,
+			// i.e. with mode switching escape sequences of ISO-2022-JP
+			joined, err := enc.Encode(part.String() + cluster)
+			if err != nil {
+				return nil, err
+			}
+
+			if uint(len(joined)) > octetLimit {
+				seg, err := enc.Encode(part.String())
+				if err != nil {
+					return nil, err
+				}
+				allSeg = append(allSeg, seg)
+				part.Reset()
+			} else {
+				n = uint(len(joined))
+			}
+			size = 0
+		}
+
+		part.WriteString(cluster)
+		size += n
+	}
+
+	if part.Len() > 0 {
+		seg, err := enc.Encode(part.String())
+		if err != nil {
+			return nil, err
+		}
+		allSeg = append(allSeg, seg)
+	}
+	return
+}
@@ -0,0 +1,103 @@
+package data
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestJIS(t *testing.T) {
+	require.EqualValues(t, 0x05, JIS.DataCoding())
+	testEncoding(t, JIS, "aあ漢", "6182a08abf")
+}
+
+func TestISO2022JP(t *testing.T) {
+	require.EqualValues(t, 0x0A, ISO2022JP.DataCoding())
+	testEncoding(t, ISO2022JP, "aあ", "611b244224221b2842")
+}
+
+func TestEXTKANJI(t *testing.T) {
+	require.EqualValues(t, 0x0D, EXTKANJI.DataCoding())
+	testEncoding(t, EXTKANJI, "aあ", "61a4a2")
+
+	// JIS X 0212 supplementary kanji
+	testEncoding(t, EXTKANJI, "丂", "8fb0a1")
+}
+
+func TestKSC5601(t *testing.T) {
+	require.EqualValues(t, 0x0E, KSC5601.DataCoding())
+	testEncoding(t, KSC5601, "a한", "61c7d1")
+}
+
+func TestCJKFromDataCoding(t *testing.T) {
+	require.Equal(t, JIS, FromDataCoding(JISCoding))
+	require.Equal(t, ISO2022JP, FromDataCoding(ISO2022JPCoding))
+	require.Equal(t, EXTKANJI, FromDataCoding(EXTKANJICoding))
+	require.Equal(t, KSC5601, FromDataCoding(KSC5601Coding))
+}
+
+func TestCJKSplit(t *testing.T) {
+	t.Run("multibyte boundary", func(t *testing.T) {
+		for _, enc := range []Encoding{JIS, EXTKANJI, KSC5601} {
+			text := "a" + strings.Repeat("漢", 100)
+			if enc == KSC5601 {
+				text = "a" + strings.Repeat("한", 100)
+			}
+
+			splitter := enc.(Splitter)
+			require.False(t, splitter.ShouldSplit(text, 201))
+			require.True(t, splitter.ShouldSplit(text, 200))
+
+			segs, err := splitter.EncodeSplit(text, 134)
+			require.NoError(t, err)
+			require.Len(t, segs, 2)
+			require.Len(t, segs[0], 133)
+			require.Len(t, segs[1], 68)
+
+			var decoded strings.Builder
+			for _, seg := range segs {
+				s, err := enc.Decode(seg)
+				require.NoError(t, err)
+				decoded.WriteString(s)
+			}
+			require.Equal(t, text, decoded.String())
+		}
+	})
+
+	t.Run("self-contained ISO-2022-JP segments", func(t *testing.T) {
+		text := strings.Repeat("あ", 100)
+
+		splitter := ISO2022JP.(Splitter)
+		require.True(t, splitter.ShouldSplit(text, 134))
+
+		segs, err := splitter.EncodeSplit(text, 134)
+		require.NoError(t, err)
+		require.Len(t, segs, 2)
+
+		var decoded strings.Builder
+		for _, seg := range segs {
+			require.LessOrEqual(t, len(seg), 134)
+			require.Equal(t, []byte{0x1b, 0x24, 0x42}, seg[:3])
+			require.Equal(t, []byte{0x1b, 0x28, 0x42}, seg[len(seg)-3:])
+
+			s, err := ISO2022JP.Decode(seg)
+			require.NoError(t, err)
+			decoded.WriteString(s)
+		}
+		require.Equal(t, text, decoded.String())
+	})
+
+	t.Run("unencodable", func(t *testing.T) {
+		splitter := KSC5601.(Splitter)
+		require.True(t, splitter.ShouldSplit("\U0001F600", 134))
+
+		_, err := splitter.EncodeSplit("\U0001F600", 134)
+		require.Error(t, err)
+	})
+	t.Run("oversize cluster", func(t *testing.T) {
+		// a single grapheme cluster of 81 UCS2 characters
+		_, err := encodeSplitMultibyte(UCS2, "e"+strings.Repeat("\u0301", 80), 134)
+		require.ErrorIs(t, err, ErrClusterTooLong)
+	})
+}
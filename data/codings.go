@@ -1,6 +1,8 @@
 package data
 
 import (
+	"unicode/utf16"
+
 	"golang.org/x/text/encoding"
 	"golang.org/x/text/encoding/charmap"
 	"golang.org/x/text/encoding/unicode"
@@ -17,12 +19,20 @@ const (
 	LATIN1Coding byte = 0x03
 	// BINARY8BIT2Coding is 8-bit binary coding
 	BINARY8BIT2Coding byte = 0x04
+	// JISCoding is JIS (X 0208-1990) coding
+	JISCoding byte = 0x05
 	// CYRILLICCoding is iso-8859-5 coding
 	CYRILLICCoding byte = 0x06
 	// HEBREWCoding is iso-8859-8 coding
 	HEBREWCoding byte = 0x07
 	// UCS2Coding is UCS2 coding
 	UCS2Coding byte = 0x08
+	// ISO2022JPCoding is iso-2022-jp (Music Codes) coding
+	ISO2022JPCoding byte = 0x0A
+	// EXTKANJICoding is extended kanji JIS (X 0212-1990) coding
+	EXTKANJICoding byte = 0x0D
+	// KSC5601Coding is KS C 5601 coding
+	KSC5601Coding byte = 0x0E
 )
 
 // EncDec wraps encoder and decoder interface.
@@ -78,6 +88,27 @@ func (c *CustomEncoding) DataCoding() byte {
 	return c.coding
 }
 
+// ShouldSplit implements Splitter, if the underlying encoder/decoder does. Otherwise text is never split.
+func (c *CustomEncoding) ShouldSplit(text string, octetLimit uint) bool {
+	if splitter, ok := c.encDec.(Splitter); ok {
+		return splitter.ShouldSplit(text, octetLimit)
+	}
+	return false
+}
+
+// EncodeSplit implements Splitter, if the underlying encoder/decoder does. Otherwise text is encoded as a single segment.
+func (c *CustomEncoding) EncodeSplit(text string, octetLimit uint) ([][]byte, error) {
+	if splitter, ok := c.encDec.(Splitter); ok {
+		return splitter.EncodeSplit(text, octetLimit)
+	}
+
+	seg, err := c.encDec.Encode(text)
+	if err != nil {
+		return nil, err
+	}
+	return [][]byte{seg}, nil
+}
+
 type gsm7bit struct {
 	packed bool
 }
@@ -308,6 +339,8 @@ func (*iso88598) Decode(data []byte) (string, error) {
 
 func (*iso88598) DataCoding() byte { return HEBREWCoding }
 
+// ucs2 encodes characters outside of Basic Multilingual Plane as UTF-16 surrogate pairs,
+// which is how handsets interpret data_coding 0x08 in practice.
 type ucs2 struct{}
 
 func (*ucs2) Encode(str string) ([]byte, error) {
@@ -321,35 +354,53 @@ func (*ucs2) Decode(data []byte) (string, error) {
 }
 
 func (*ucs2) ShouldSplit(text string, octetLimit uint) (shouldSplit bool) {
-	runeSlice := []rune(text)
-	return uint(len(runeSlice)*2) > octetLimit
+	octets := 0
+	for _, r := range text {
+		octets += 2 * utf16.RuneLen(r)
+	}
+	return uint(octets) > octetLimit
 }
 
+// EncodeSplit splits text at grapheme cluster boundaries, so that neither a surrogate pair
+// nor a combined character (e.g. emoji ZWJ sequence, flag, letter with combining mark)
+// is cut across segments. A grapheme cluster longer than octetLimit is split at code point boundaries.
 func (c *ucs2) EncodeSplit(text string, octetLimit uint) (allSeg [][]byte, err error) {
 	if octetLimit < 64 {
 		octetLimit = 134
 	}
 
 	allSeg = [][]byte{}
-	runeSlice := []rune(text)
-	hextetLim := int(octetLimit / 2) // round down
-
-	// hextet = 16 bits, the correct terms should be hexadectet
-	fr, to := 0, hextetLim
-	for fr < len(runeSlice) {
-		if to > len(runeSlice) {
-			to = len(runeSlice)
+	seg := make([]byte, 0, octetLimit)
+	appendEncoded := func(encoded []byte) {
+		if uint(len(seg)+len(encoded)) > octetLimit && len(seg) > 0 {
+			allSeg = append(allSeg, seg)
+			seg = make([]byte, 0, octetLimit)
 		}
+		seg = append(seg, encoded...)
+	}
 
-		seg, err := c.Encode(string(runeSlice[fr:to]))
+	for _, cluster := range graphemeClusters(text) {
+		encoded, err := c.Encode(cluster)
 		if err != nil {
 			return nil, err
 		}
-		allSeg = append(allSeg, seg)
 
-		fr, to = to, to+hextetLim
+		if uint(len(encoded)) <= octetLimit {
+			appendEncoded(encoded)
+			continue
+		}
+
+		for _, r := range cluster {
+			if encoded, err = c.Encode(string(r)); err != nil {
+				return nil, err
+			}
+			appendEncoded(encoded)
+		}
 	}
 
+	if len(seg) > 0 {
+		allSeg = append(allSeg, seg)
+	}
 	return
 }
 
@@ -384,6 +435,18 @@ var (
 
 	// UCS2 encoding.
 	UCS2 Encoding = &ucs2{}
+
+	// JIS is JIS X 0208 encoding, in Shift_JIS form.
+	JIS Encoding = &jis{}
+
+	// ISO2022JP encoding.
+	ISO2022JP Encoding = &iso2022jp{}
+
+	// EXTKANJI is extended kanji JIS X 0212 encoding, in EUC-JP form.
+	EXTKANJI Encoding = &extendedKanjiJIS{}
+
+	// KSC5601 is KS C 5601 encoding, in EUC-KR form.
+	KSC5601 Encoding = &ksc5601{}
 )
 
 var codingMap = map[byte]Encoding{
@@ -395,15 +458,18 @@ var codingMap = map[byte]Encoding{
 	CYRILLICCoding:    CYRILLIC,
 	HEBREWCoding:      HEBREW,
 	UCS2Coding:        UCS2,
+	JISCoding:         JIS,
+	ISO2022JPCoding:   ISO2022JP,
+	EXTKANJICoding:    EXTKANJI,
+	KSC5601Coding:     KSC5601,
 }
 
 // FromDataCoding returns encoding from DataCoding value.
+//
+// Values other than SMPP specific ones are decoded by DataCodingScheme, e.g. 0x18 is UCS2 flash message.
+// Encodings registered by RegisterEncoding take precedence.
 func FromDataCoding(code byte) (enc Encoding) {
-	enc, ok := codingMap[code]
-	if !ok { // if encoding is reserved (custom)
-		enc = NewCustomEncoding(code, GSM7BIT) // GSM7BIT is a temporary patch to apply to Encode/Decode methods
-	}
-	return
+	return DataCodingScheme(code).Encoding()
 }
 
 // Splitter extend encoding object by defining a split function
@@ -3,6 +3,7 @@ package data
 import (
 	"encoding/hex"
 	"log"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -64,7 +65,8 @@ func shiftBitsOneRight(input []byte) []byte {
 }
 
 func TestCoding(t *testing.T) {
-	require.Nil(t, FromDataCoding(12))
+	// SMPP specific value without dedicated encoding keeps its data coding
+	require.Equal(t, NewCustomEncoding(12, GSM7BIT), FromDataCoding(12))
 	require.Equal(t, GSM7BIT, FromDataCoding(0))
 	require.Equal(t, ASCII, FromDataCoding(1))
 	require.Equal(t, UCS2, FromDataCoding(8))
@@ -104,6 +106,10 @@ func TestShouldSplit(t *testing.T) {
 			"ợÁÊGỷẹííỡỮÂIỆàúễẠỮỊệÂỖÍắẵYẠừẲíộờíẵỠựẤằờởể̃ởỵởềệổồUỡỵầễÁÝởÝNè̉ỚổôỊộợKỨệ́": true,  /* 71 UCS2 chars */
 		}
 
+		// surrogate pairs take 4 octets
+		expect[strings.Repeat("😀", 35)] = false
+		expect[strings.Repeat("😀", 35)+"a"] = true
+
 		splitter, _ := UCS2.(Splitter)
 		for k, v := range expect {
 			ok := splitter.ShouldSplit(k, octetLim)
@@ -172,6 +178,36 @@ func TestSplit(t *testing.T) {
 			})
 	})
 
+	// surrogate pair should not be splitted in the middle, 33 "a" and emoji take 70 octets
+	t.Run("testSplit_SurrogatePair_UCS2", func(t *testing.T) {
+		testEncodingSplit(t, UCS2,
+			68,
+			strings.Repeat("a", 33)+"\U0001F600",
+			[]string{
+				strings.Repeat("0061", 33),
+				"d83dde00",
+			},
+			[]string{
+				strings.Repeat("a", 33),
+				"\U0001F600",
+			})
+	})
+
+	// emoji with skin tone modifier should not be splitted
+	t.Run("testSplit_GraphemeCluster_UCS2", func(t *testing.T) {
+		testEncodingSplit(t, UCS2,
+			68,
+			strings.Repeat("a", 31)+"\U0001F44D\U0001F3FD",
+			[]string{
+				strings.Repeat("0061", 31),
+				"d83ddc4dd83cdffd",
+			},
+			[]string{
+				strings.Repeat("a", 31),
+				"\U0001F44D\U0001F3FD",
+			})
+	})
+
 	// UCS2 character should not be splitted in the middle
 	// here 54 character is encoded to 108 octet, but since there are 107 octet limit,
 	// a whole 2 octet has to be carried over to the next segment
@@ -325,6 +361,9 @@ func TestAscii(t *testing.T) {
 func TestUCS2(t *testing.T) {
 	require.EqualValues(t, 8, UCS2.DataCoding())
 	testEncoding(t, UCS2, "agjwklgjkwP", "00610067006a0077006b006c0067006a006b00770050")
+
+	// characters outside BMP are encoded as surrogate pairs
+	testEncoding(t, UCS2, "a\U0001F600", "0061d83dde00")
 }
 
 func TestLatin1(t *testing.T) {
@@ -0,0 +1,219 @@
+package data
+
+// DataCodingScheme is data_coding value, decoded according to coding groups of
+// 3GPP TS 23.038 (GSM 03.38) section 4.
+//
+// Values 0x00 to 0x0F are SMPP specific alphabets, as defined by SMPP 3.4 section 5.2.19.
+type DataCodingScheme byte
+
+// CodingGroup is coding group of DataCodingScheme.
+type CodingGroup byte
+
+// Coding groups.
+const (
+	// CodingGroupGeneral is general data coding group (00xx xxxx), including SMPP specific values.
+	CodingGroupGeneral CodingGroup = iota
+
+	// CodingGroupAutomaticDeletion is general data coding group, with message marked for automatic deletion (01xx xxxx).
+	CodingGroupAutomaticDeletion
+
+	// CodingGroupReserved is reserved coding group (1000 xxxx to 1011 xxxx).
+	CodingGroupReserved
+
+	// CodingGroupMWIDiscard is message waiting indication group, discard message (1100 xxxx).
+	CodingGroupMWIDiscard
+
+	// CodingGroupMWIStoreGSM7 is message waiting indication group, store message in GSM 7-bit (1101 xxxx).
+	CodingGroupMWIStoreGSM7
+
+	// CodingGroupMWIStoreUCS2 is message waiting indication group, store message in UCS2 (1110 xxxx).
+	CodingGroupMWIStoreUCS2
+
+	// CodingGroupMessageClass is data coding/message class group (1111 xxxx).
+	CodingGroupMessageClass
+)
+
+// Alphabet is character set of DataCodingScheme.
+type Alphabet byte
+
+// Alphabets.
+const (
+	// AlphabetGSM7 is GSM 7-bit default alphabet.
+	AlphabetGSM7 Alphabet = iota
+
+	// Alphabet8Bit is 8-bit data.
+	Alphabet8Bit
+
+	// AlphabetUCS2 is UCS2.
+	AlphabetUCS2
+
+	// AlphabetReserved is reserved alphabet.
+	AlphabetReserved
+
+	// AlphabetOther is SMPP specific alphabet other than GSM 7-bit, 8-bit data and UCS2,
+	// e.g. IA5, Latin-1 or JIS. See Encoding.
+	AlphabetOther
+)
+
+// MessageClass is message class of DataCodingScheme.
+type MessageClass byte
+
+// Message classes.
+const (
+	// MessageClass0 is immediate display (flash message).
+	MessageClass0 MessageClass = iota
+
+	// MessageClass1 is mobile equipment specific.
+	MessageClass1
+
+	// MessageClass2 is SIM specific.
+	MessageClass2
+
+	// MessageClass3 is terminal equipment specific.
+	MessageClass3
+)
+
+// IndicationType is message waiting indication type.
+type IndicationType byte
+
+// Indication types.
+const (
+	IndicationVoicemail IndicationType = iota
+	IndicationFax
+	IndicationEmail
+	IndicationOther
+)
+
+// NewDataCodingScheme returns general DataCodingScheme with given alphabet and message class,
+// e.g. GSM 7-bit flash message with MessageClass0.
+func NewDataCodingScheme(alphabet Alphabet, class MessageClass) DataCodingScheme {
+	return DataCodingScheme(0x10 | (byte(alphabet)&0x03)<<2 | byte(class)&0x03)
+}
+
+// isSMPP reports whether scheme is SMPP specific value.
+func (d DataCodingScheme) isSMPP() bool {
+	return d < 0x10
+}
+
+// Group returns coding group.
+func (d DataCodingScheme) Group() CodingGroup {
+	switch d >> 4 {
+	case 0x0, 0x1, 0x2, 0x3:
+		return CodingGroupGeneral
+	case 0x4, 0x5, 0x6, 0x7:
+		return CodingGroupAutomaticDeletion
+	case 0xC:
+		return CodingGroupMWIDiscard
+	case 0xD:
+		return CodingGroupMWIStoreGSM7
+	case 0xE:
+		return CodingGroupMWIStoreUCS2
+	case 0xF:
+		return CodingGroupMessageClass
+	default:
+		return CodingGroupReserved
+	}
+}
+
+// Alphabet returns character set of message.
+func (d DataCodingScheme) Alphabet() Alphabet {
+	if d.isSMPP() {
+		switch byte(d) {
+		case GSM7BITCoding:
+			return AlphabetGSM7
+		case BINARY8BIT1Coding, BINARY8BIT2Coding:
+			return Alphabet8Bit
+		case UCS2Coding:
+			return AlphabetUCS2
+		default:
+			return AlphabetOther
+		}
+	}
+
+	switch d.Group() {
+	case CodingGroupGeneral, CodingGroupAutomaticDeletion:
+		return Alphabet((d >> 2) & 0x03)
+	case CodingGroupMWIDiscard, CodingGroupMWIStoreGSM7:
+		return AlphabetGSM7
+	case CodingGroupMWIStoreUCS2:
+		return AlphabetUCS2
+	case CodingGroupMessageClass:
+		if d&0x04 > 0 {
+			return Alphabet8Bit
+		}
+		return AlphabetGSM7
+	default:
+		return AlphabetReserved
+	}
+}
+
+// MessageClass returns message class, if any.
+func (d DataCodingScheme) MessageClass() (class MessageClass, ok bool) {
+	switch d.Group() {
+	case CodingGroupGeneral, CodingGroupAutomaticDeletion:
+		ok = !d.isSMPP() && d&0x10 > 0
+	case CodingGroupMessageClass:
+		ok = true
+	}
+
+	if ok {
+		class = MessageClass(d & 0x03)
+	}
+	return
+}
+
+// Compressed reports whether message is compressed.
+func (d DataCodingScheme) Compressed() bool {
+	switch d.Group() {
+	case CodingGroupGeneral, CodingGroupAutomaticDeletion:
+		return d&0x20 > 0
+	default:
+		return false
+	}
+}
+
+// AutomaticDeletion reports whether message is marked for automatic deletion.
+func (d DataCodingScheme) AutomaticDeletion() bool {
+	return d.Group() == CodingGroupAutomaticDeletion
+}
+
+// MessageWaiting returns message waiting indication, if any.
+// Message of CodingGroupMWIDiscard group may be discarded after updating indication.
+func (d DataCodingScheme) MessageWaiting() (active bool, kind IndicationType, ok bool) {
+	switch d.Group() {
+	case CodingGroupMWIDiscard, CodingGroupMWIStoreGSM7, CodingGroupMWIStoreUCS2:
+		return d&0x08 > 0, IndicationType(d & 0x03), true
+	default:
+		return
+	}
+}
+
+// Encoding returns encoding of message, whose DataCoding is the scheme itself.
+//
+// Encoding registered by RegisterEncoding is returned first. SMPP specific values have their own
+// encodings, if supported. Compressed message and reserved alphabet are treated as 8-bit data,
+// since they can not be decoded as text.
+func (d DataCodingScheme) Encoding() Encoding {
+	if enc, ok := RegisteredEncoding(byte(d)); ok {
+		return enc
+	}
+
+	if enc, ok := codingMap[byte(d)]; ok {
+		return enc
+	}
+
+	if d.isSMPP() {
+		return NewCustomEncoding(byte(d), GSM7BIT) // GSM7BIT is a temporary patch to apply to Encode/Decode methods
+	}
+
+	var enc Encoding
+	switch alphabet := d.Alphabet(); {
+	case d.Compressed(), alphabet == Alphabet8Bit, alphabet == AlphabetReserved:
+		enc = BINARY8BIT2
+	case alphabet == AlphabetUCS2:
+		enc = UCS2
+	default:
+		enc = GSM7BIT
+	}
+	return NewCustomEncoding(byte(d), enc)
+}
@@ -0,0 +1,91 @@
+package data
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDataCodingScheme(t *testing.T) {
+	t.Run("SMPP", func(t *testing.T) {
+		dcs := DataCodingScheme(LATIN1Coding)
+		require.Equal(t, CodingGroupGeneral, dcs.Group())
+		require.Equal(t, AlphabetOther, dcs.Alphabet())
+		require.Equal(t, LATIN1, dcs.Encoding())
+
+		_, ok := dcs.MessageClass()
+		require.False(t, ok)
+
+		require.Equal(t, AlphabetUCS2, DataCodingScheme(UCS2Coding).Alphabet())
+		require.Equal(t, Alphabet8Bit, DataCodingScheme(BINARY8BIT2Coding).Alphabet())
+	})
+
+	t.Run("general", func(t *testing.T) {
+		dcs := NewDataCodingScheme(AlphabetUCS2, MessageClass0)
+		require.EqualValues(t, 0x18, dcs)
+		require.Equal(t, CodingGroupGeneral, dcs.Group())
+		require.Equal(t, AlphabetUCS2, dcs.Alphabet())
+		require.False(t, dcs.Compressed())
+
+		class, ok := dcs.MessageClass()
+		require.True(t, ok)
+		require.Equal(t, MessageClass0, class)
+
+		enc := dcs.Encoding()
+		require.EqualValues(t, 0x18, enc.DataCoding())
+		testEncoding(t, enc, "Hi", "00480069")
+
+		// compressed, with message marked for automatic deletion
+		dcs = DataCodingScheme(0x62)
+		require.Equal(t, CodingGroupAutomaticDeletion, dcs.Group())
+		require.True(t, dcs.AutomaticDeletion())
+		require.True(t, dcs.Compressed())
+		require.Equal(t, AlphabetGSM7, dcs.Alphabet())
+		_, err := dcs.Encoding().Decode([]byte{0x01})
+		require.ErrorIs(t, err, ErrNotImplDecode)
+	})
+
+	t.Run("MWI", func(t *testing.T) {
+		active, kind, ok := DataCodingScheme(0xC9).MessageWaiting()
+		require.True(t, ok)
+		require.True(t, active)
+		require.Equal(t, IndicationFax, kind)
+		require.Equal(t, CodingGroupMWIDiscard, DataCodingScheme(0xC9).Group())
+
+		dcs := DataCodingScheme(0xE2)
+		require.Equal(t, CodingGroupMWIStoreUCS2, dcs.Group())
+		require.Equal(t, AlphabetUCS2, dcs.Alphabet())
+		active, kind, ok = dcs.MessageWaiting()
+		require.True(t, ok)
+		require.False(t, active)
+		require.Equal(t, IndicationEmail, kind)
+
+		_, _, ok = DataCodingScheme(0x10).MessageWaiting()
+		require.False(t, ok)
+	})
+
+	t.Run("messageClass", func(t *testing.T) {
+		dcs := DataCodingScheme(0xF6)
+		require.Equal(t, CodingGroupMessageClass, dcs.Group())
+		require.Equal(t, Alphabet8Bit, dcs.Alphabet())
+
+		class, ok := dcs.MessageClass()
+		require.True(t, ok)
+		require.Equal(t, MessageClass2, class)
+
+		require.Equal(t, AlphabetGSM7, DataCodingScheme(0xF0).Alphabet())
+		require.Equal(t, CodingGroupReserved, DataCodingScheme(0x80).Group())
+		require.Equal(t, AlphabetReserved, DataCodingScheme(0x80).Alphabet())
+	})
+
+	t.Run("FromDataCoding", func(t *testing.T) {
+		enc := FromDataCoding(0x10)
+		require.EqualValues(t, 0x10, enc.DataCoding())
+		testEncoding(t, enc, "abc", "616263")
+
+		// long message is split as GSM 7-bit
+		splitter, ok := enc.(Splitter)
+		require.True(t, ok)
+		require.True(t, splitter.ShouldSplit(string(make([]byte, 141)), 140))
+	})
+}
@@ -0,0 +1,117 @@
+package data
+
+import (
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	zeroWidthJoiner    = '\u200d'
+	zeroWidthNonJoiner = '\u200c'
+)
+
+// graphemeClusters splits text into extended grapheme clusters, following the rules of
+// Unicode UAX #29 which matter for short messages: CR LF, combining marks, emoji modifier and
+// ZWJ sequences, flags (regional indicator pairs) and Hangul syllables.
+func graphemeClusters(text string) (clusters []string) {
+	start, riCount := 0, 0
+	prev, _ := utf8.DecodeRuneInString(text)
+	if isRegionalIndicator(prev) {
+		riCount = 1
+	}
+
+	for i, r := range text {
+		if i == 0 {
+			continue
+		}
+
+		if graphemeBreak(prev, r, riCount) {
+			clusters = append(clusters, text[start:i])
+			start = i
+		}
+
+		if isRegionalIndicator(r) {
+			riCount++
+		} else {
+			riCount = 0
+		}
+		prev = r
+	}
+
+	if start < len(text) {
+		clusters = append(clusters, text[start:])
+	}
+	return
+}
+
+// graphemeBreak reports whether there is a grapheme cluster boundary between prev and next.
+// riCount is the number of consecutive regional indicators ending with prev.
+func graphemeBreak(prev, next rune, riCount int) bool {
+	switch {
+	case prev == '\r' && next == '\n':
+		return false
+
+	case isGraphemeControl(prev) || isGraphemeControl(next):
+		return true
+
+	case isHangulL(prev) && (isHangulL(next) || isHangulV(next) || isHangulLV(next) || isHangulLVT(next)):
+		return false
+
+	case (isHangulLV(prev) || isHangulV(prev)) && (isHangulV(next) || isHangulT(next)):
+		return false
+
+	case (isHangulLVT(prev) || isHangulT(prev)) && isHangulT(next):
+		return false
+
+	case isGraphemeExtend(next) || unicode.Is(unicode.Mc, next):
+		return false
+
+	case prev == zeroWidthJoiner && isPictographic(next):
+		return false
+
+	case isRegionalIndicator(prev) && isRegionalIndicator(next):
+		return riCount%2 == 0
+	}
+	return true
+}
+
+func isGraphemeControl(r rune) bool {
+	return r != zeroWidthJoiner && r != zeroWidthNonJoiner &&
+		(unicode.Is(unicode.Cc, r) || unicode.Is(unicode.Zl, r) || unicode.Is(unicode.Zp, r))
+}
+
+func isGraphemeExtend(r rune) bool {
+	return unicode.Is(unicode.Mn, r) || unicode.Is(unicode.Me, r) ||
+		r == zeroWidthJoiner || r == zeroWidthNonJoiner ||
+		(r >= 0x1F3FB && r <= 0x1F3FF) || // emoji modifiers
+		(r >= 0xE0020 && r <= 0xE007F) // tags
+}
+
+func isPictographic(r rune) bool {
+	return unicode.Is(unicode.So, r) || (r >= 0x1F000 && r <= 0x1FAFF) || (r >= 0x2600 && r <= 0x27BF)
+}
+
+func isRegionalIndicator(r rune) bool {
+	return r >= 0x1F1E6 && r <= 0x1F1FF
+}
+
+func isHangulL(r rune) bool {
+	return (r >= 0x1100 && r <= 0x115F) || (r >= 0xA960 && r <= 0xA97C)
+}
+
+func isHangulV(r rune) bool {
+	return (r >= 0x1160 && r <= 0x11A7) || (r >= 0xD7B0 && r <= 0xD7C6)
+}
+
+func isHangulT(r rune) bool {
+	return (r >= 0x11A8 && r <= 0x11FF) || (r >= 0xD7CB && r <= 0xD7FB)
+}
+
+// isHangulLV reports whether r is a precomposed Hangul syllable without final consonant.
+func isHangulLV(r rune) bool {
+	return r >= 0xAC00 && r <= 0xD7A3 && (r-0xAC00)%28 == 0
+}
+
+func isHangulLVT(r rune) bool {
+	return r >= 0xAC00 && r <= 0xD7A3 && (r-0xAC00)%28 != 0
+}
@@ -0,0 +1,40 @@
+package data
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGraphemeClusters(t *testing.T) {
+	cases := map[string][]string{
+		"":       nil,
+		"ab":     {"a", "b"},
+		"a\r\nb": {"a", "\r\n", "b"},
+
+		// combining mark
+		"éa": {"é", "a"},
+
+		// surrogate pairs, emoji modifier
+		"\U0001F600\U0001F44D\U0001F3FD": {"\U0001F600", "\U0001F44D\U0001F3FD"},
+
+		// ZWJ sequence
+		"\U0001F468‍\U0001F469‍\U0001F467x": {"\U0001F468‍\U0001F469‍\U0001F467", "x"},
+
+		// flags
+		"\U0001F1FB\U0001F1F3\U0001F1EF\U0001F1F5\U0001F1FA": {"\U0001F1FB\U0001F1F3", "\U0001F1EF\U0001F1F5", "\U0001F1FA"},
+
+		// variation selector
+		"❤️!": {"❤️", "!"},
+
+		// Hangul jamo
+		"각한": {"각", "한"},
+
+		// Devanagari virama and vowel sign
+		"क्षि": {"क्", "षि"},
+	}
+
+	for text, expected := range cases {
+		require.Equal(t, expected, graphemeClusters(text), text)
+	}
+}
@@ -38,6 +38,14 @@ const (
 	ALERT_NOTIFICATION    = CommandIDType(0x00000102)
 	DATA_SM               = CommandIDType(0x00000103)
 	DATA_SM_RESP          = CommandIDType(-2147483389)
+
+	// SMPP 5.0 Command ID Set
+	BROADCAST_SM             = CommandIDType(0x00000111)
+	BROADCAST_SM_RESP        = CommandIDType(-2147483375)
+	QUERY_BROADCAST_SM       = CommandIDType(0x00000112)
+	QUERY_BROADCAST_SM_RESP  = CommandIDType(-2147483374)
+	CANCEL_BROADCAST_SM      = CommandIDType(0x00000113)
+	CANCEL_BROADCAST_SM_RESP = CommandIDType(-2147483373)
 )
 
 // nolint
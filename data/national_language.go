@@ -0,0 +1,441 @@
+package data
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"unicode/utf8"
+)
+
+// ErrUnsupportedNationalLanguage means there is no shift table for given national language.
+var ErrUnsupportedNationalLanguage = errors.New("unsupported national language shift table")
+
+// NationalLanguage identifies GSM 7-bit national language shift tables,
+// as defined in 3GPP TS 23.038 section 6.2.1.2.4.
+type NationalLanguage byte
+
+// National languages of 3GPP TS 23.038.
+const (
+	// NationalLanguageDefault is the GSM 7-bit default alphabet and its extension table.
+	NationalLanguageDefault    NationalLanguage = 0
+	NationalLanguageTurkish    NationalLanguage = 1
+	NationalLanguageSpanish    NationalLanguage = 2
+	NationalLanguagePortuguese NationalLanguage = 3
+	NationalLanguageBengali    NationalLanguage = 4
+	NationalLanguageGujarati   NationalLanguage = 5
+	NationalLanguageHindi      NationalLanguage = 6
+	NationalLanguageKannada    NationalLanguage = 7
+	NationalLanguageMalayalam  NationalLanguage = 8
+	NationalLanguageOriya      NationalLanguage = 9
+	NationalLanguagePunjabi    NationalLanguage = 10
+	NationalLanguageTamil      NationalLanguage = 11
+	NationalLanguageTelugu     NationalLanguage = 12
+	NationalLanguageUrdu       NationalLanguage = 13
+)
+
+// NationalLanguageShifter is implemented by GSM 7-bit encodings using national language shift tables.
+//
+// Non-default tables must be signalled to receiver with UDH IEs: national language
+// locking shift (0x25) and national language single shift (0x24).
+type NationalLanguageShifter interface {
+	NationalLanguages() (locking, single NationalLanguage)
+}
+
+// shiftTable maps between septets and characters.
+type shiftTable struct {
+	reverse [128]rune
+	forward map[rune]byte
+}
+
+// undefinedChar marks undefined position of shift table, i.e. escape position.
+const undefinedChar = rune(0xFFFF)
+
+func newShiftTable(reverse map[byte]rune) *shiftTable {
+	t := &shiftTable{forward: make(map[rune]byte, len(reverse))}
+	for i := range t.reverse {
+		t.reverse[i] = undefinedChar
+	}
+
+	// ascending order, so the first position wins for duplicated characters
+	for i := 0; i < len(t.reverse); i++ {
+		if r, ok := reverse[byte(i)]; ok && r != undefinedChar {
+			t.reverse[i] = r
+			if _, ok := t.forward[r]; !ok {
+				t.forward[r] = byte(i)
+			}
+		}
+	}
+	return t
+}
+
+// newLockingShiftTable creates table from 128 characters. Escape (0x1B) position must be undefined.
+func newLockingShiftTable(table string) *shiftTable {
+	if n := utf8.RuneCountInString(table); n != 128 {
+		panic(fmt.Sprintf("locking shift table must have 128 characters, got %d", n))
+	}
+
+	reverse := make(map[byte]rune, 128)
+	i := 0
+	for _, r := range table {
+		reverse[byte(i)] = r
+		i++
+	}
+	return newShiftTable(reverse)
+}
+
+// newIndicSingleShiftTable creates single shift table of Indian national languages or Urdu.
+// Besides given script specific characters, e.g. digits, these tables share punctuation,
+// brackets, Latin capital letters and euro sign.
+func newIndicSingleShiftTable(chars map[byte]rune) *shiftTable {
+	reverse := map[byte]rune{
+		0x00: '@', 0x01: '£', 0x02: '$', 0x03: '¥', 0x04: '¿', 0x05: '"', 0x06: '¤', 0x07: '%',
+		0x08: '&', 0x09: '\'', 0x0A: '\f', 0x0B: '*', 0x0C: '+', 0x0E: '-', 0x0F: '/',
+		0x10: '<', 0x11: '=', 0x12: '>', 0x13: '¡', 0x14: '^', 0x15: '¡', 0x16: '_', 0x17: '#',
+		0x18: '*', 0x28: '{', 0x29: '}', 0x2F: '\\', 0x3C: '[', 0x3D: '~', 0x3E: ']', 0x40: '|',
+		0x65: '€',
+	}
+	for i := byte(0); i < 26; i++ {
+		reverse[0x41+i] = 'A' + rune(i)
+	}
+	for b, r := range chars {
+		reverse[b] = r
+	}
+	return newShiftTable(reverse)
+}
+
+func (t *shiftTable) lookup(r rune) (b byte, ok bool) {
+	b, ok = t.forward[r]
+	return
+}
+
+func (t *shiftTable) character(b byte) (r rune, ok bool) {
+	if int(b) < len(t.reverse) {
+		r = t.reverse[b]
+	}
+	return r, r != undefinedChar && int(b) < len(t.reverse)
+}
+
+var lockingShiftTables = map[NationalLanguage]*shiftTable{
+	NationalLanguageDefault: newShiftTable(reverseLookup),
+
+	NationalLanguageTurkish: newLockingShiftTable("" +
+		"@£$¥€éùıòÇ\nĞğ\rÅå" +
+		"Δ_ΦΓΛΩΠΨΣΘΞ\uffffŞşßÉ" +
+		" !\"#¤%&'()*+,-./0123456789:;<=>?" +
+		"İABCDEFGHIJKLMNOPQRSTUVWXYZÄÖÑÜ§" +
+		"çabcdefghijklmnopqrstuvwxyzäöñüà"),
+
+	// Spanish has no locking shift table, default alphabet is used
+
+	NationalLanguagePortuguese: newLockingShiftTable("" +
+		"@£$¥êéúíóç\nÔô\rÁá" +
+		"Δ_ªÇÀ∞^\\€Ó|\uffffÂâÊÉ" +
+		" !\"#º%&'()*+,-./0123456789:;<=>?" +
+		"ÍABCDEFGHIJKLMNOPQRSTUVWXYZÃÕÚÜ§" +
+		"~abcdefghijklmnopqrstuvwxyzãõ`üà"),
+
+	NationalLanguageBengali: newLockingShiftTable("" +
+		"ঁংঃঅআইঈউঊঋ\nঌ\uffff\r\uffffএ" +
+		"ঐ\uffff\uffffওঔকখগঘঙচ\uffffছজঝঞ" +
+		" !টঠডঢণত)(থদ,ধ.ন" +
+		"0123456789:;\uffffপফ?" +
+		"বভমযর\uffffল\uffff\uffff\uffffশষসহ়ঽ" +
+		"ািীুূৃৄ\uffff\uffffেৈ\uffff\uffffোৌ্" +
+		"ৎabcdefghijklmnopqrstuvwxyzৗড়ঢ়ৰৱ"),
+
+	NationalLanguageGujarati: newLockingShiftTable("" +
+		"ઁંઃઅઆઇઈઉઊઋ\nઌઍ\r\uffffએ" +
+		"ઐઑ\uffffઓઔકખગઘઙચ\uffffછજઝઞ" +
+		" !ટઠડઢણત)(થદ,ધ.ન" +
+		"0123456789:;\uffffપફ?" +
+		"બભમયર\uffffલળ\uffffવશષસહ઼ઽ" +
+		"ાિીુૂૃૄૅ\uffffેૈૉ\uffffોૌ્" +
+		"ૐabcdefghijklmnopqrstuvwxyzૠૡૢૣ૱"),
+
+	NationalLanguageHindi: newLockingShiftTable("" +
+		"ँंःअआइईउऊऋ\nऌऍ\rऎए" +
+		"ऐऑऒओऔकखगघङच\uffffछजझञ" +
+		" !टठडढणत)(थद,ध.न" +
+		"0123456789:;ऩपफ?" +
+		"बभमयरऱलळऴवशषसह़ऽ" +
+		"ािीुूृॄॅॆेैॉॊोौ्" +
+		"ॐabcdefghijklmnopqrstuvwxyzॲॻॼॾॿ"),
+
+	NationalLanguageKannada: newLockingShiftTable("" +
+		"\uffffಂಃಅಆಇಈಉಊಋ\nಌ\uffff\rಎಏ" +
+		"ಐ\uffffಒಓಔಕಖಗಘಙಚ\uffffಛಜಝಞ" +
+		" !ಟಠಡಢಣತ)(ಥದ,ಧ.ನ" +
+		"0123456789:;\uffffಪಫ?" +
+		"ಬಭಮಯರಱಲಳ\uffffವಶಷಸಹ಼ಽ" +
+		"ಾಿೀುೂೃೄ\uffffೆೇೈ\uffffೊೋೌ್" +
+		"ೕabcdefghijklmnopqrstuvwxyzೖೠೡೢೣ"),
+
+	NationalLanguageMalayalam: newLockingShiftTable("" +
+		"\uffffംഃഅആഇഈഉഊഋ\nഌ\uffff\rഎഏ" +
+		"ഐ\uffffഒഓഔകഖഗഘങച\uffffഛജഝഞ" +
+		" !ടഠഡഢണത)(ഥദ,ധ.ന" +
+		"0123456789:;\uffffപഫ?" +
+		"ബഭമയരറലളഴവശഷസഹ\uffffഽ" +
+		"ാിീുൂൃൄ\uffffെേൈ\uffffൊോൌ്" +
+		"ൗabcdefghijklmnopqrstuvwxyzൠൡൢൣ൹"),
+
+	NationalLanguageOriya: newLockingShiftTable("" +
+		"ଁଂଃଅଆଇଈଉଊଋ\nଌ\uffff\r\uffffଏ" +
+		"ଐ\uffff\uffffଓଔକଖଗଘଙଚ\uffffଛଜଝଞ" +
+		" !ଟଠଡଢଣତ)(ଥଦ,ଧ.ନ" +
+		"0123456789:;\uffffପଫ?" +
+		"ବଭମଯର\uffffଲଳ\uffffଵଶଷସହ଼ଽ" +
+		"ାିୀୁୂୃୄ\uffff\uffffେୈ\uffff\uffffୋୌ୍" +
+		"ୖabcdefghijklmnopqrstuvwxyzୗୠୡୢୣ"),
+
+	NationalLanguagePunjabi: newLockingShiftTable("" +
+		"ਁਂਃਅਆਇਈਉਊ\uffff\n\uffff\uffff\r\uffffਏ" +
+		"ਐ\uffff\uffffਓਔਕਖਗਘਙਚ\uffffਛਜਝਞ" +
+		" !ਟਠਡਢਣਤ)(ਥਦ,ਧ.ਨ" +
+		"0123456789:;\uffffਪਫ?" +
+		"ਬਭਮਯਰ\uffffਲਲ਼\uffffਵਸ਼\uffffਸਹ਼\uffff" +
+		"ਾਿੀੁੂ\uffff\uffff\uffff\uffffੇੈ\uffff\uffffੋੌ੍" +
+		"ੰabcdefghijklmnopqrstuvwxyzੱੲੳੴ\uffff"),
+
+	NationalLanguageTamil: newLockingShiftTable("" +
+		"\uffffஂஃஅஆஇஈஉஊ\uffff\n\uffff\uffff\rஎஏ" +
+		"ஐ\uffffஒஓஔக\uffff\uffff\uffffஙச\uffff\uffffஜ\uffffஞ" +
+		" !ட\uffff\uffff\uffffணத)(\uffff\uffff,\uffff.ந" +
+		"0123456789:;னப\uffff?" +
+		"\uffff\uffffமயரறலளழவஶஷஸஹ\uffff\uffff" +
+		"ாிீுூ\uffff\uffff\uffffெேை\uffffொோௌ்" +
+		"ௐabcdefghijklmnopqrstuvwxyzௗ௰௱௲௹"),
+
+	NationalLanguageTelugu: newLockingShiftTable("" +
+		"ఁంఃఅఆఇఈఉఊఋ\nఌ\uffff\rఎఏ" +
+		"ఐ\uffffఒఓఔకఖగఘఙచ\uffffఛజఝఞ" +
+		" !టఠడఢణత)(థద,ధ.న" +
+		"0123456789:;\uffffపఫ?" +
+		"బభమయరఱలళ\uffffవశషసహ\uffffఽ" +
+		"ాిీుూృౄ\uffffెేై\uffffొోౌ్" +
+		"ౕabcdefghijklmnopqrstuvwxyzౖౠౡౢౣ"),
+
+	NationalLanguageUrdu: newLockingShiftTable("" +
+		"اآبٻڀپڦتۂٿ\nٹٽ\rٺټ" +
+		"ثجځڄڃڅچڇحخد\uffffڌڈډڊ" +
+		" !ڏڍذرڑړ)(ڙز,ږ.ژ" +
+		"0123456789:;ښسش?" +
+		"صضطظعفقکڪګگڳڱلمن" +
+		"ںڻڼوۄەہھءیېےٍُِٗ" +
+		"ٔabcdefghijklmnopqrstuvwxyzّٰٕٖٓ"),
+}
+
+var singleShiftTables = map[NationalLanguage]*shiftTable{
+	NationalLanguageDefault: newShiftTable(reverseEscape),
+
+	NationalLanguageTurkish: newShiftTable(map[byte]rune{
+		0x0A: '\f', 0x14: '^', 0x28: '{', 0x29: '}', 0x2F: '\\', 0x3C: '[', 0x3D: '~', 0x3E: ']', 0x40: '|',
+		0x47: 'Ğ', 0x49: 'İ', 0x53: 'Ş', 0x63: 'ç', 0x65: '€', 0x67: 'ğ', 0x69: 'ı', 0x73: 'ş',
+	}),
+
+	NationalLanguageSpanish: newShiftTable(map[byte]rune{
+		0x09: 'ç', 0x0A: '\f', 0x14: '^', 0x28: '{', 0x29: '}', 0x2F: '\\', 0x3C: '[', 0x3D: '~', 0x3E: ']', 0x40: '|',
+		0x41: 'Á', 0x49: 'Í', 0x4F: 'Ó', 0x55: 'Ú', 0x61: 'á', 0x65: '€', 0x69: 'í', 0x6F: 'ó', 0x75: 'ú',
+	}),
+
+	NationalLanguagePortuguese: newShiftTable(map[byte]rune{
+		0x05: 'ê', 0x09: 'ç', 0x0A: '\f', 0x0B: 'Ô', 0x0C: 'ô', 0x0E: 'Á', 0x0F: 'á',
+		0x12: 'Φ', 0x13: 'Γ', 0x14: '^', 0x15: 'Ω', 0x16: 'Π', 0x17: 'Ψ', 0x18: 'Σ', 0x19: 'Θ', 0x1F: 'Ê',
+		0x28: '{', 0x29: '}', 0x2F: '\\', 0x3C: '[', 0x3D: '~', 0x3E: ']', 0x40: '|',
+		0x41: 'À', 0x49: 'Í', 0x4F: 'Ó', 0x55: 'Ú', 0x5B: 'Ã', 0x5C: 'Õ',
+		0x61: 'Â', 0x65: '€', 0x69: 'í', 0x6F: 'ó', 0x75: 'ú', 0x7B: 'ã', 0x7C: 'õ', 0x7F: 'â',
+	}),
+
+	NationalLanguageBengali: newIndicSingleShiftTable(map[byte]rune{
+		0x19: '।', 0x1A: '॥',
+		0x1C: '০', 0x1D: '১', 0x1E: '২', 0x1F: '৩',
+		0x20: '৪', 0x21: '৫', 0x22: '৬', 0x23: '৭', 0x24: '৮', 0x25: '৯',
+		0x26: 'য়', 0x27: 'ৠ', 0x2A: 'ৡ', 0x2B: 'ৢ', 0x2C: 'ৣ', 0x2D: '৲', 0x2E: '৳',
+		0x30: '৴', 0x31: '৵', 0x32: '৶', 0x33: '৷', 0x34: '৸', 0x35: '৹', 0x36: '৺',
+	}),
+
+	NationalLanguageGujarati: newIndicSingleShiftTable(map[byte]rune{
+		0x19: '।', 0x1A: '॥',
+		0x1C: '૦', 0x1D: '૧', 0x1E: '૨', 0x1F: '૩',
+		0x20: '૪', 0x21: '૫', 0x22: '૬', 0x23: '૭', 0x24: '૮', 0x25: '૯',
+	}),
+
+	NationalLanguageHindi: newIndicSingleShiftTable(map[byte]rune{
+		0x19: '।', 0x1A: '॥',
+		0x1C: '०', 0x1D: '१', 0x1E: '२', 0x1F: '३',
+		0x20: '४', 0x21: '५', 0x22: '६', 0x23: '७', 0x24: '८', 0x25: '९',
+		0x26: '॑', 0x27: '॒', 0x2A: '॓', 0x2B: '॔', 0x2C: 'क़', 0x2D: 'ख़', 0x2E: 'ग़',
+		0x30: 'ज़', 0x31: 'ड़', 0x32: 'ढ़', 0x33: 'फ़', 0x34: 'य़',
+		0x35: 'ॠ', 0x36: 'ॡ', 0x37: 'ॢ', 0x38: 'ॣ', 0x39: '॰', 0x3A: 'ॱ',
+	}),
+
+	NationalLanguageKannada: newIndicSingleShiftTable(map[byte]rune{
+		0x19: '।', 0x1A: '॥',
+		0x1C: '೦', 0x1D: '೧', 0x1E: '೨', 0x1F: '೩',
+		0x20: '೪', 0x21: '೫', 0x22: '೬', 0x23: '೭', 0x24: '೮', 0x25: '೯',
+		0x26: 'ೞ', 0x27: 'ೱ', 0x2A: 'ೲ',
+	}),
+
+	NationalLanguageMalayalam: newIndicSingleShiftTable(map[byte]rune{
+		0x19: '।', 0x1A: '॥',
+		0x1C: '൦', 0x1D: '൧', 0x1E: '൨', 0x1F: '൩',
+		0x20: '൪', 0x21: '൫', 0x22: '൬', 0x23: '൭', 0x24: '൮', 0x25: '൯',
+		0x26: '൰', 0x27: '൱', 0x2A: '൲', 0x2B: '൳', 0x2C: '൴', 0x2D: '൵', 0x2E: 'ൺ',
+		0x30: 'ൻ', 0x31: 'ർ', 0x32: 'ൽ', 0x33: 'ൾ', 0x34: 'ൿ',
+	}),
+
+	NationalLanguageOriya: newIndicSingleShiftTable(map[byte]rune{
+		0x19: '।', 0x1A: '॥',
+		0x1C: '୦', 0x1D: '୧', 0x1E: '୨', 0x1F: '୩',
+		0x20: '୪', 0x21: '୫', 0x22: '୬', 0x23: '୭', 0x24: '୮', 0x25: '୯',
+		0x26: 'ଡ଼', 0x27: 'ଢ଼', 0x2A: 'ୟ', 0x2B: '୰', 0x2C: 'ୱ',
+	}),
+
+	NationalLanguagePunjabi: newIndicSingleShiftTable(map[byte]rune{
+		0x19: '।', 0x1A: '॥',
+		0x1C: '੦', 0x1D: '੧', 0x1E: '੨', 0x1F: '੩',
+		0x20: '੪', 0x21: '੫', 0x22: '੬', 0x23: '੭', 0x24: '੮', 0x25: '੯',
+		0x26: 'ਖ਼', 0x27: 'ਗ਼', 0x2A: 'ਜ਼', 0x2B: 'ੜ', 0x2C: 'ਫ਼', 0x2D: 'ੵ',
+	}),
+
+	NationalLanguageTamil: newIndicSingleShiftTable(map[byte]rune{
+		0x19: '।', 0x1A: '॥',
+		0x1C: '௦', 0x1D: '௧', 0x1E: '௨', 0x1F: '௩',
+		0x20: '௪', 0x21: '௫', 0x22: '௬', 0x23: '௭', 0x24: '௮', 0x25: '௯',
+		0x26: '௳', 0x27: '௴', 0x2A: '௵', 0x2B: '௶', 0x2C: '௷', 0x2D: '௸', 0x2E: '௺',
+	}),
+
+	NationalLanguageTelugu: newIndicSingleShiftTable(map[byte]rune{
+		0x1C: '౦', 0x1D: '౧', 0x1E: '౨', 0x1F: '౩',
+		0x20: '౪', 0x21: '౫', 0x22: '౬', 0x23: '౭', 0x24: '౮', 0x25: '౯',
+		0x26: 'ౘ', 0x27: 'ౙ', 0x2A: '౸', 0x2B: '౹', 0x2C: '౺', 0x2D: '౻', 0x2E: '౼',
+		0x30: '౽', 0x31: '౾', 0x32: '౿',
+	}),
+
+	NationalLanguageUrdu: newIndicSingleShiftTable(map[byte]rune{
+		0x19: '؀', 0x1A: '؁',
+		0x1C: '۰', 0x1D: '۱', 0x1E: '۲', 0x1F: '۳',
+		0x20: '۴', 0x21: '۵', 0x22: '۶', 0x23: '۷', 0x24: '۸', 0x25: '۹',
+		0x26: '،', 0x27: '؍', 0x2A: '؎', 0x2B: '؏', 0x2C: 'ؐ', 0x2D: 'ؑ', 0x2E: 'ؒ',
+		0x30: 'ؓ', 0x31: 'ؔ', 0x32: '؛', 0x33: '؟', 0x34: 'ـ', 0x35: 'ْ', 0x36: '٘', 0x37: '٫',
+		0x38: '٬', 0x39: 'ٲ', 0x3A: 'ٳ', 0x3B: 'ۍ', 0x3F: '۔',
+	}),
+}
+
+// SupportedNationalLanguages returns national languages having locking shift table
+// and national languages having single shift table.
+func SupportedNationalLanguages() (locking, single []NationalLanguage) {
+	return slices.Sorted(maps.Keys(lockingShiftTables)), slices.Sorted(maps.Keys(singleShiftTables))
+}
+
+type gsm7National struct {
+	lockingLang, singleLang NationalLanguage
+	locking, single         *shiftTable
+}
+
+// GSM7National returns unpacked GSM 7-bit encoding using given national language locking shift table
+// and single shift table. NationalLanguageDefault means the default alphabet or its extension table.
+//
+// When used by ShortMessage, the required UDH IEs are added automatically.
+func GSM7National(locking, single NationalLanguage) (Encoding, error) {
+	if locking == NationalLanguageDefault && single == NationalLanguageDefault {
+		return GSM7BIT, nil
+	}
+
+	l, ok := lockingShiftTables[locking]
+	if !ok {
+		return nil, fmt.Errorf("%w: locking shift %d", ErrUnsupportedNationalLanguage, locking)
+	}
+
+	s, ok := singleShiftTables[single]
+	if !ok {
+		return nil, fmt.Errorf("%w: single shift %d", ErrUnsupportedNationalLanguage, single)
+	}
+
+	return &gsm7National{
+		lockingLang: locking,
+		singleLang:  single,
+		locking:     l,
+		single:      s,
+	}, nil
+}
+
+func (c *gsm7National) NationalLanguages() (locking, single NationalLanguage) {
+	return c.lockingLang, c.singleLang
+}
+
+func (c *gsm7National) DataCoding() byte { return GSM7BITCoding }
+
+// encodeRune appends septets of given rune.
+func (c *gsm7National) encodeRune(septets []byte, r rune) ([]byte, error) {
+	if b, ok := c.locking.lookup(r); ok {
+		return append(septets, b), nil
+	}
+	if b, ok := c.single.lookup(r); ok {
+		return append(septets, escapeSequence, b), nil
+	}
+	return septets, ErrInvalidCharacter
+}
+
+func (c *gsm7National) Encode(str string) (septets []byte, err error) {
+	septets = make([]byte, 0, len(str))
+	for _, r := range str {
+		if septets, err = c.encodeRune(septets, r); err != nil {
+			return nil, err
+		}
+	}
+	return
+}
+
+func (c *gsm7National) Decode(data []byte) (string, error) {
+	runes := make([]rune, 0, len(data))
+	for i := 0; i < len(data); i++ {
+		table := c.locking
+		if data[i] == escapeSequence {
+			if i++; i >= len(data) {
+				return "", ErrInvalidByte
+			}
+			table = c.single
+		}
+
+		r, ok := table.character(data[i])
+		if !ok {
+			return "", ErrInvalidByte
+		}
+		runes = append(runes, r)
+	}
+	return string(runes), nil
+}
+
+func (c *gsm7National) ShouldSplit(text string, octetLimit uint) (shouldSplit bool) {
+	septets, err := c.Encode(text)
+	return err != nil || uint(len(septets)) > octetLimit
+}
+
+// EncodeSplit splits text into segments of at most octetLimit septets,
+// never separating escape sequence from its character.
+func (c *gsm7National) EncodeSplit(text string, octetLimit uint) (allSeg [][]byte, err error) {
+	if octetLimit < 64 {
+		octetLimit = 134
+	}
+
+	allSeg = [][]byte{}
+	seg := make([]byte, 0, octetLimit)
+	for _, r := range text {
+		n := len(seg)
+		if seg, err = c.encodeRune(seg, r); err != nil {
+			return nil, err
+		}
+
+		if uint(len(seg)) > octetLimit {
+			allSeg = append(allSeg, seg[:n])
+			seg = append(make([]byte, 0, octetLimit), seg[n:]...)
+		}
+	}
+
+	if len(seg) > 0 {
+		allSeg = append(allSeg, seg)
+	}
+	return
+}
@@ -0,0 +1,147 @@
+package data
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNationalLanguageTables(t *testing.T) {
+	for lang, table := range lockingShiftTables {
+		_, ok := table.character(escapeSequence)
+		require.False(t, ok, "escape position of locking shift table %d must be undefined", lang)
+	}
+	for lang, table := range singleShiftTables {
+		_, ok := table.character(escapeSequence)
+		require.False(t, ok, "escape position of single shift table %d must be undefined", lang)
+	}
+
+	locking, single := SupportedNationalLanguages()
+	require.Equal(t, []NationalLanguage{
+		NationalLanguageDefault, NationalLanguageTurkish, NationalLanguagePortuguese,
+		NationalLanguageBengali, NationalLanguageGujarati, NationalLanguageHindi, NationalLanguageKannada,
+		NationalLanguageMalayalam, NationalLanguageOriya, NationalLanguagePunjabi, NationalLanguageTamil,
+		NationalLanguageTelugu, NationalLanguageUrdu,
+	}, locking)
+	require.Equal(t, []NationalLanguage{
+		NationalLanguageDefault, NationalLanguageTurkish, NationalLanguageSpanish, NationalLanguagePortuguese,
+		NationalLanguageBengali, NationalLanguageGujarati, NationalLanguageHindi, NationalLanguageKannada,
+		NationalLanguageMalayalam, NationalLanguageOriya, NationalLanguagePunjabi, NationalLanguageTamil,
+		NationalLanguageTelugu, NationalLanguageUrdu,
+	}, single)
+
+	// Indian and Urdu locking shift tables keep digits, punctuation and Latin small letters in place
+	for lang := NationalLanguageBengali; lang <= NationalLanguageUrdu; lang++ {
+		table := lockingShiftTables[lang]
+		for b, r := range map[byte]rune{0x0A: '\n', 0x0D: '\r', 0x20: ' ', 0x21: '!', 0x2C: ',', 0x2E: '.', 0x3F: '?'} {
+			require.Equal(t, r, table.reverse[b], "language %d, position %#x", lang, b)
+		}
+		for i := byte(0); i < 10; i++ {
+			require.Equal(t, '0'+rune(i), table.reverse[0x30+i], "language %d", lang)
+		}
+		for i := byte(0); i < 26; i++ {
+			require.Equal(t, 'a'+rune(i), table.reverse[0x61+i], "language %d", lang)
+			require.Equal(t, 'A'+rune(i), singleShiftTables[lang].reverse[0x41+i], "language %d", lang)
+		}
+	}
+}
+
+func TestGSM7National(t *testing.T) {
+	t.Run("default", func(t *testing.T) {
+		enc, err := GSM7National(NationalLanguageDefault, NationalLanguageDefault)
+		require.Nil(t, err)
+		require.Equal(t, GSM7BIT, enc)
+	})
+
+	t.Run("unsupported", func(t *testing.T) {
+		_, err := GSM7National(NationalLanguageSpanish, NationalLanguageDefault)
+		require.ErrorIs(t, err, ErrUnsupportedNationalLanguage)
+
+		// reserved national language identifiers
+		for _, lang := range []NationalLanguage{14, 0x7F} {
+			_, err = GSM7National(lang, NationalLanguageDefault)
+			require.ErrorIs(t, err, ErrUnsupportedNationalLanguage)
+
+			_, err = GSM7National(NationalLanguageDefault, lang)
+			require.ErrorIs(t, err, ErrUnsupportedNationalLanguage)
+		}
+	})
+
+	t.Run("turkish", func(t *testing.T) {
+		enc, err := GSM7National(NationalLanguageTurkish, NationalLanguageTurkish)
+		require.Nil(t, err)
+		require.Equal(t, GSM7BITCoding, enc.DataCoding())
+
+		locking, single := enc.(NationalLanguageShifter).NationalLanguages()
+		require.Equal(t, NationalLanguageTurkish, locking)
+		require.Equal(t, NationalLanguageTurkish, single)
+
+		// Ş, ı and ş from locking shift table, { from single shift table
+		testEncoding(t, enc, "Şıkış {", "1c076b071d201b28")
+
+		_, err = enc.Encode("Ø")
+		require.ErrorIs(t, err, ErrInvalidCharacter)
+
+		_, err = enc.Decode([]byte{0x1b})
+		require.ErrorIs(t, err, ErrInvalidByte)
+	})
+
+	t.Run("spanish", func(t *testing.T) {
+		enc, err := GSM7National(NationalLanguageDefault, NationalLanguageSpanish)
+		require.Nil(t, err)
+		testEncoding(t, enc, "Árbol ç", "1b4172626f6c201b09")
+	})
+
+	t.Run("portuguese", func(t *testing.T) {
+		enc, err := GSM7National(NationalLanguagePortuguese, NationalLanguagePortuguese)
+		require.Nil(t, err)
+		testEncoding(t, enc, "Ação", "41097b6f")
+	})
+
+	t.Run("hindi", func(t *testing.T) {
+		enc, err := GSM7National(NationalLanguageHindi, NationalLanguageHindi)
+		require.Nil(t, err)
+		testEncoding(t, enc, "नमस्ते १", "2f424c5f2759201b1d")
+	})
+
+	t.Run("indic", func(t *testing.T) {
+		for _, tc := range []struct {
+			lang     NationalLanguage
+			text     string
+			expected string
+		}{
+			{NationalLanguageBengali, "নমস্কার ১", "2f424c5f155044201b1d"},
+			{NationalLanguageTamil, "வணக்கம் ௧", "4926155f15425f201b1d"},
+			{NationalLanguageUrdu, "سلام ۱", "3d4d004e201b1d"},
+		} {
+			enc, err := GSM7National(tc.lang, tc.lang)
+			require.Nil(t, err)
+			testEncoding(t, enc, tc.text, tc.expected)
+		}
+	})
+
+	t.Run("split", func(t *testing.T) {
+		enc, err := GSM7National(NationalLanguageTurkish, NationalLanguageTurkish)
+		require.Nil(t, err)
+
+		splitter := enc.(Splitter)
+		require.False(t, splitter.ShouldSplit(strings.Repeat("ı", 140), 140))
+		require.True(t, splitter.ShouldSplit(strings.Repeat("{", 71), 140))
+
+		// escape sequence is never split
+		segments, err := splitter.EncodeSplit("a"+strings.Repeat("{", 70), 134)
+		require.Nil(t, err)
+		require.Len(t, segments, 2)
+		require.Len(t, segments[0], 133)
+		require.Len(t, segments[1], 8)
+
+		var decoded strings.Builder
+		for _, seg := range segments {
+			s, err := enc.Decode(seg)
+			require.Nil(t, err)
+			decoded.WriteString(s)
+		}
+		require.Equal(t, "a"+strings.Repeat("{", 70), decoded.String())
+	})
+}
@@ -39,6 +39,7 @@ const (
 	// Interface_Version
 	SMPP_V33 int8 = int8(-0x33)
 	SMPP_V34      = byte(0x34)
+	SMPP_V50      = byte(0x50)
 
 	// Address_TON
 	GSM_TON_UNKNOWN       = byte(0x00)
@@ -318,8 +319,10 @@ const (
 	OPT_PAR_MSG_PAYLOAD_MAX = 1500
 
 	// User Data Header
-	UDH_CONCAT_MSG_8_BIT_REF  = byte(0x00)
-	UDH_CONCAT_MSG_16_BIT_REF = byte(0x08)
+	UDH_CONCAT_MSG_8_BIT_REF   = byte(0x00)
+	UDH_CONCAT_MSG_16_BIT_REF  = byte(0x08)
+	UDH_NATIONAL_SINGLE_SHIFT  = byte(0x24)
+	UDH_NATIONAL_LOCKING_SHIFT = byte(0x25)
 
 	/**
 	 * @deprecated As of version 1.3 of the library there are defined
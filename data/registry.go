@@ -0,0 +1,58 @@
+package data
+
+import "sync"
+
+var (
+	registryLock sync.RWMutex
+	registry     = map[byte]Encoding{}
+)
+
+// RegisterEncoding registers encoding of given data_coding value globally, taking precedence over
+// built-in ones, e.g. LATIN1 for 0x00 if SMSC default alphabet is ISO-8859-1.
+//
+// Encoding whose DataCoding differs from given value is wrapped, so that marshaled data_coding
+// is kept as is. Nil encoding unregisters the value.
+func RegisterEncoding(code byte, enc Encoding) {
+	registryLock.Lock()
+	if enc == nil {
+		delete(registry, code)
+	} else {
+		registry[code] = withDataCoding(code, enc)
+	}
+	registryLock.Unlock()
+}
+
+// RegisteredEncoding returns globally registered encoding of given data_coding value, if any.
+func RegisteredEncoding(code byte) (enc Encoding, ok bool) {
+	registryLock.RLock()
+	enc, ok = registry[code]
+	registryLock.RUnlock()
+	return
+}
+
+// Encodings overrides encodings of data_coding values, e.g. per session.
+type Encodings map[byte]Encoding
+
+// Lookup returns overriding encoding of given data_coding value, falling back
+// to globally registered one.
+func (e Encodings) Lookup(code byte) (Encoding, bool) {
+	if enc, ok := e[code]; ok && enc != nil {
+		return withDataCoding(code, enc), true
+	}
+	return RegisteredEncoding(code)
+}
+
+// FromDataCoding returns encoding of given data_coding value, honoring overrides.
+func (e Encodings) FromDataCoding(code byte) Encoding {
+	if enc, ok := e.Lookup(code); ok {
+		return enc
+	}
+	return FromDataCoding(code)
+}
+
+func withDataCoding(code byte, enc Encoding) Encoding {
+	if enc.DataCoding() == code {
+		return enc
+	}
+	return NewCustomEncoding(code, enc)
+}
@@ -0,0 +1,51 @@
+package data
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegisterEncoding(t *testing.T) {
+	RegisterEncoding(GSM7BITCoding, LATIN1)
+	defer RegisterEncoding(GSM7BITCoding, nil)
+
+	enc := FromDataCoding(GSM7BITCoding)
+	require.EqualValues(t, GSM7BITCoding, enc.DataCoding())
+	testEncoding(t, enc, "café", "636166e9")
+
+	registered, ok := RegisteredEncoding(GSM7BITCoding)
+	require.True(t, ok)
+	require.Equal(t, enc, registered)
+
+	// same data_coding is not wrapped
+	RegisterEncoding(0x0C, NewCustomEncoding(0x0C, UCS2))
+	defer RegisterEncoding(0x0C, nil)
+	require.Equal(t, NewCustomEncoding(0x0C, UCS2), FromDataCoding(0x0C))
+
+	// unregister
+	RegisterEncoding(GSM7BITCoding, nil)
+	require.Equal(t, GSM7BIT, FromDataCoding(GSM7BITCoding))
+	_, ok = RegisteredEncoding(GSM7BITCoding)
+	require.False(t, ok)
+}
+
+func TestEncodings(t *testing.T) {
+	encodings := Encodings{GSM7BITCoding: CYRILLIC}
+
+	enc, ok := encodings.Lookup(GSM7BITCoding)
+	require.True(t, ok)
+	require.EqualValues(t, GSM7BITCoding, enc.DataCoding())
+	testEncoding(t, enc, "Привет", "bfe0d8d2d5e2")
+
+	_, ok = encodings.Lookup(UCS2Coding)
+	require.False(t, ok)
+	require.Equal(t, UCS2, encodings.FromDataCoding(UCS2Coding))
+
+	// falls back to registered encodings
+	RegisterEncoding(UCS2Coding, NewCustomEncoding(UCS2Coding, UTF16LE))
+	defer RegisterEncoding(UCS2Coding, nil)
+
+	testEncoding(t, encodings.FromDataCoding(UCS2Coding), "Hi", "48006900")
+	require.Equal(t, GSM7BIT, Encodings(nil).FromDataCoding(GSM7BITCoding))
+}
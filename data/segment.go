@@ -0,0 +1,315 @@
+package data
+
+import (
+	"errors"
+	"strings"
+	"unicode/utf8"
+)
+
+// ErrNoSuitableEncoding means none of the given encodings could represent a text.
+var ErrNoSuitableEncoding = errors.New("no suitable encoding for text")
+
+// ConcatUDHLength is the length of UDH carrying concatenation IE with 8-bit reference number,
+// including the UDHL byte.
+const ConcatUDHLength = 6
+
+// GSM7FillBits returns the number of fill bits between UDH of given length, UDHL byte included,
+// and packed GSM 7-bit user data, so that the first septet starts on a septet boundary.
+func GSM7FillBits(udhLength int) int {
+	return (7 - udhLength*8%7) % 7
+}
+
+// RemoveFillBits strips fill bits preceding packed GSM 7-bit user data, which is received after UDH.
+func RemoveFillBits(userData []byte, fillBits int) []byte {
+	if fillBits == 0 || len(userData) == 0 {
+		return userData
+	}
+
+	septets := (len(userData)*8 - fillBits) / 7
+	if (len(userData)*8-fillBits)%7 == 0 && userData[len(userData)-1]>>1 == 0x0D {
+		septets-- // CR filling 7 spare bits of the last octet
+	}
+
+	packed := make([]byte, (septets*7+7)/8)
+	for i := range packed {
+		packed[i] = userData[i] >> fillBits
+		if i+1 < len(userData) {
+			packed[i] |= userData[i+1] << (8 - fillBits)
+		}
+	}
+	if used := septets * 7 % 8; used > 0 {
+		packed[len(packed)-1] &= 1<<used - 1 // clear spare bits
+	}
+	return packed
+}
+
+// addFillBits shifts packed septets left by fill bits, so that they follow UDH on a septet boundary.
+// When 7 bits are left spare in the last octet, they are filled with CR, not to be taken as '@'.
+func addFillBits(packed []byte, septets, fillBits int) []byte {
+	bits := fillBits + 7*septets
+
+	userData := make([]byte, (bits+7)/8)
+	for i := range userData {
+		if i < len(packed) {
+			userData[i] = packed[i] << fillBits
+		}
+		if i > 0 && fillBits > 0 {
+			userData[i] |= packed[i-1] >> (8 - fillBits)
+		}
+	}
+
+	if bits%8 == 1 {
+		userData[len(userData)-1] |= 0x0D << 1
+	}
+	return userData
+}
+
+// SegmentEstimate describes short messages carrying a text with an encoding.
+type SegmentEstimate struct {
+	Encoding Encoding
+
+	// Segments is the number of short messages.
+	Segments int
+
+	// Characters is the number of characters carried by each segment.
+	Characters []int
+
+	// Octets is the length of encoded user data of each segment, UDH excluded.
+	Octets []int
+
+	// TotalOctets is the total length of encoded user data, UDH excluded.
+	TotalOctets int
+}
+
+// EstimateSegments estimates short messages carrying text with given encoding,
+// concatenated with 8-bit reference number UDH if needed.
+//
+// National language IEs required by encoding are accounted.
+// The calculation is the same as one used by ShortMessage when splitting long message.
+func EstimateSegments(text string, enc Encoding) (e SegmentEstimate, err error) {
+	s, err := segment(text, enc, ConcatUDHLength)
+	if err != nil {
+		return
+	}
+
+	e = SegmentEstimate{
+		Encoding:   enc,
+		Segments:   len(s.data),
+		Characters: s.characters,
+		Octets:     make([]int, len(s.data)),
+	}
+	for i := range s.data {
+		e.Octets[i] = len(s.data[i])
+		e.TotalOctets += len(s.data[i])
+	}
+	return
+}
+
+// SelectEncoding returns estimate of the encoding, among allowed ones, yielding the fewest segments.
+// Ties are resolved by order of allowed encodings, so the preferred one should come first.
+//
+// If no encoding is given, GSM7BIT and UCS2 are considered.
+// Returns ErrNoSuitableEncoding if none of the encodings could represent text.
+func SelectEncoding(text string, allowed ...Encoding) (best SegmentEstimate, err error) {
+	if len(allowed) == 0 {
+		allowed = []Encoding{GSM7BIT, UCS2}
+	}
+
+	found := false
+	for _, enc := range allowed {
+		e, er := EstimateSegments(text, enc)
+		if er != nil {
+			continue
+		}
+
+		if !found || e.Segments < best.Segments {
+			best, found = e, true
+		}
+	}
+
+	if !found {
+		err = ErrNoSuitableEncoding
+	}
+	return
+}
+
+// EncodeSegments encodes text into user data of short messages, each segment leaving concatUDHL octets
+// for concatenation UDH, e.g. 6 for 8-bit reference number, 7 for 16-bit reference number or 0 when
+// concatenation info is carried by TLVs. National language IEs required by encoding are accounted.
+//
+// Returns a single segment if text fits into a short message.
+func EncodeSegments(text string, enc Encoding, concatUDHL uint) (segments [][]byte, err error) {
+	s, err := segment(text, enc, concatUDHL)
+	if err == nil {
+		segments = s.data
+	}
+	return
+}
+
+// segmentLimits returns octet limits of user data for text fitting into a single short message and
+// for each segment of concatenated message.
+func segmentLimits(enc Encoding, concatUDHL uint) (single, concat uint) {
+	var ies uint
+	if shifter, ok := enc.(NationalLanguageShifter); ok {
+		locking, single := shifter.NationalLanguages()
+		if locking != NationalLanguageDefault {
+			ies++
+		}
+		if single != NationalLanguageDefault {
+			ies++
+		}
+	}
+
+	single, concat = SM_GSM_MSG_LEN, SM_GSM_MSG_LEN-concatUDHL
+	if ies > 0 {
+		// each IE takes 3 octets, plus UDHL byte if there is no concatenation UDH
+		single -= 1 + 3*ies
+		if concatUDHL == 0 {
+			concat--
+		}
+		concat -= 3 * ies
+	}
+	return
+}
+
+type segmentation struct {
+	data       [][]byte
+	characters []int
+}
+
+// segment splits text at character boundaries.
+//
+// Built-in text encodings are measured by grapheme cluster, so that neither an escaped GSM 7-bit
+// character, UTF-16 surrogate pair nor combined character is split. Other encodings are split by their Splitter if implemented.
+func segment(text string, enc Encoding, concatUDHL uint) (s *segmentation, err error) {
+	if enc == nil {
+		enc = GSM7BIT
+	}
+	single, concat := segmentLimits(enc, concatUDHL)
+
+	septets := false
+	switch c := enc.(type) {
+	case *gsm7bitPacked:
+		septets = true
+
+	case *gsm7bit:
+		septets = c.packed
+
+	case *gsm7National, *ucs2, *iso88591, *iso88595, *iso88598:
+
+	default:
+		return segmentBySplitter(text, enc, single, concat)
+	}
+
+	// limits in septets for packed GSM 7-bit, concatenated segments starting after fill bits
+	singleUnits, concatUnits := single, concat
+	fillBits := GSM7FillBits(int(SM_GSM_MSG_LEN - concat))
+	if septets {
+		singleUnits, concatUnits = single*8/7, (concat*8-uint(fillBits))/7
+	}
+
+	s = &segmentation{}
+
+	measurer := enc
+	if septets {
+		measurer = GSM7BIT
+	}
+
+	// text is split at grapheme cluster boundaries, or code point boundaries within a cluster
+	// which does not fit into a segment
+	var (
+		pieces []textPiece
+		total  uint
+		chars  int
+	)
+	for _, cluster := range graphemeClusters(text) {
+		encoded, er := measurer.Encode(cluster)
+		if er != nil {
+			return nil, er
+		}
+		total += uint(len(encoded))
+
+		if uint(len(encoded)) <= concatUnits {
+			n := utf8.RuneCountInString(cluster)
+			pieces = append(pieces, textPiece{text: cluster, units: uint(len(encoded)), characters: n})
+			chars += n
+			continue
+		}
+
+		for _, r := range cluster {
+			if encoded, er = measurer.Encode(string(r)); er != nil {
+				return nil, er
+			}
+			pieces = append(pieces, textPiece{text: string(r), units: uint(len(encoded)), characters: 1})
+			chars++
+		}
+	}
+
+	if total <= singleUnits {
+		var d []byte
+		if d, err = enc.Encode(text); err != nil {
+			return nil, err
+		}
+		s.data, s.characters = [][]byte{d}, []int{chars}
+		return
+	}
+
+	for fr := 0; fr < len(pieces); {
+		var (
+			part       strings.Builder
+			n          uint
+			characters int
+			to         = fr
+		)
+		for ; to < len(pieces) && n+pieces[to].units <= concatUnits; to++ {
+			part.WriteString(pieces[to].text)
+			n += pieces[to].units
+			characters += pieces[to].characters
+		}
+		if to == fr { // limit is too small for even one character
+			return nil, ErrInvalidCharacter
+		}
+
+		var d []byte
+		if d, err = enc.Encode(part.String()); err != nil {
+			return nil, err
+		}
+		if septets {
+			d = addFillBits(d, int(n), fillBits)
+		}
+
+		s.data = append(s.data, d)
+		s.characters = append(s.characters, characters)
+		fr = to
+	}
+	return
+}
+
+type textPiece struct {
+	text       string
+	units      uint
+	characters int
+}
+
+// segmentBySplitter splits text using Splitter implemented by encoding. Characters are counted
+// by decoding segments.
+func segmentBySplitter(text string, enc Encoding, single, concat uint) (s *segmentation, err error) {
+	s = &segmentation{}
+
+	splitter, ok := enc.(Splitter)
+	if !ok || !splitter.ShouldSplit(text, single) {
+		var d []byte
+		if d, err = enc.Encode(text); err != nil {
+			return nil, err
+		}
+		s.data = [][]byte{d}
+	} else if s.data, err = splitter.EncodeSplit(text, concat); err != nil {
+		return nil, err
+	}
+
+	for _, d := range s.data {
+		decoded, _ := enc.Decode(d)
+		s.characters = append(s.characters, len([]rune(decoded)))
+	}
+	return
+}
@@ -0,0 +1,136 @@
+package data
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEstimateSegments(t *testing.T) {
+	t.Run("GSM7BIT", func(t *testing.T) {
+		e, err := EstimateSegments(strings.Repeat("a", 140), GSM7BIT)
+		require.Nil(t, err)
+		require.Equal(t, 1, e.Segments)
+		require.Equal(t, []int{140}, e.Characters)
+		require.Equal(t, 140, e.TotalOctets)
+
+		// escaped character is never split
+		e, err = EstimateSegments(strings.Repeat("a", 133)+"€"+strings.Repeat("b", 10), GSM7BIT)
+		require.Nil(t, err)
+		require.Equal(t, 2, e.Segments)
+		require.Equal(t, []int{133, 11}, e.Characters)
+		require.Equal(t, []int{133, 12}, e.Octets)
+		require.Equal(t, 145, e.TotalOctets)
+	})
+
+	t.Run("GSM7BITPACKED", func(t *testing.T) {
+		e, err := EstimateSegments(strings.Repeat("a", 160), GSM7BITPACKED)
+		require.Nil(t, err)
+		require.Equal(t, 1, e.Segments)
+		require.Equal(t, []int{140}, e.Octets)
+
+		e, err = EstimateSegments(strings.Repeat("a", 161), GSM7BITPACKED)
+		require.Nil(t, err)
+		require.Equal(t, []int{153, 8}, e.Characters)
+		require.Equal(t, []int{134, 8}, e.Octets)
+	})
+
+	t.Run("UCS2", func(t *testing.T) {
+		e, err := EstimateSegments(strings.Repeat("ư", 71), UCS2)
+		require.Nil(t, err)
+		require.Equal(t, []int{67, 4}, e.Characters)
+		require.Equal(t, []int{134, 8}, e.Octets)
+
+		// flag is carried by the second segment as a whole
+		e, err = EstimateSegments(strings.Repeat("a", 67)+"\U0001F1FB\U0001F1F3", UCS2)
+		require.Nil(t, err)
+		require.Equal(t, []int{67, 2}, e.Characters)
+		require.Equal(t, []int{134, 8}, e.Octets)
+	})
+
+	t.Run("NationalLanguage", func(t *testing.T) {
+		enc, err := GSM7National(NationalLanguageTurkish, NationalLanguageTurkish)
+		require.Nil(t, err)
+
+		// 7 octets taken by national language IEs
+		e, err := EstimateSegments(strings.Repeat("ş", 133), enc)
+		require.Nil(t, err)
+		require.Equal(t, []int{133}, e.Characters)
+
+		e, err = EstimateSegments(strings.Repeat("ş", 134), enc)
+		require.Nil(t, err)
+		require.Equal(t, []int{128, 6}, e.Characters)
+	})
+
+	t.Run("invalidCharacter", func(t *testing.T) {
+		_, err := EstimateSegments("ş", GSM7BIT)
+		require.ErrorIs(t, err, ErrInvalidCharacter)
+
+		_, err = EstimateSegments("ş", LATIN1)
+		require.Error(t, err)
+	})
+}
+
+func TestSelectEncoding(t *testing.T) {
+	e, err := SelectEncoding("hello")
+	require.Nil(t, err)
+	require.Equal(t, GSM7BIT, e.Encoding)
+
+	e, err = SelectEncoding("xin chào bạn")
+	require.Nil(t, err)
+	require.Equal(t, UCS2, e.Encoding)
+
+	// national language shift tables avoid UCS2
+	turkish, err := GSM7National(NationalLanguageTurkish, NationalLanguageTurkish)
+	require.Nil(t, err)
+
+	text := strings.Repeat("Şişli ", 20)
+	e, err = SelectEncoding(text, GSM7BIT, LATIN1, turkish, UCS2)
+	require.Nil(t, err)
+	require.Equal(t, turkish, e.Encoding)
+	require.Equal(t, 1, e.Segments)
+
+	// tie is resolved by order
+	e, err = SelectEncoding("é", LATIN1, GSM7BIT)
+	require.Nil(t, err)
+	require.Equal(t, LATIN1, e.Encoding)
+
+	_, err = SelectEncoding("ş", GSM7BIT, LATIN1)
+	require.ErrorIs(t, err, ErrNoSuitableEncoding)
+}
+
+func TestEncodeSegments(t *testing.T) {
+	segments, err := EncodeSegments(strings.Repeat("a", 141), GSM7BIT, 0)
+	require.Nil(t, err)
+	require.Len(t, segments, 2)
+	require.Len(t, segments[0], 140)
+
+	segments, err = EncodeSegments(strings.Repeat("a", 141), GSM7BIT, 7)
+	require.Nil(t, err)
+	require.Len(t, segments, 2)
+	require.Len(t, segments[0], 133)
+}
+
+func TestGSM7FillBits(t *testing.T) {
+	require.Equal(t, 1, GSM7FillBits(ConcatUDHLength))
+	require.Equal(t, 0, GSM7FillBits(ConcatUDHLength+1)) // 16-bit reference number
+	require.Equal(t, 5, GSM7FillBits(ConcatUDHLength+3)) // national language IE
+
+	text := strings.Repeat("a", 161)
+	for _, udhl := range []int{ConcatUDHLength, ConcatUDHLength + 1, ConcatUDHLength + 3} {
+		segments, err := EncodeSegments(text, GSM7BITPACKED, uint(udhl))
+		require.Nil(t, err)
+		require.Len(t, segments, 2)
+
+		var decoded string
+		for _, seg := range segments {
+			require.LessOrEqual(t, udhl+len(seg), SM_GSM_MSG_LEN)
+
+			s, err := GSM7BITPACKED.Decode(RemoveFillBits(seg, GSM7FillBits(udhl)))
+			require.Nil(t, err)
+			decoded += s
+		}
+		require.Equal(t, text, decoded)
+	}
+}
@@ -0,0 +1,151 @@
+package data
+
+// Replacement is a character replaced by transliteration.
+type Replacement struct {
+	// Index of the replaced character in original text, counted in characters (runes).
+	Index int
+
+	// Original character.
+	Original rune
+
+	// Replacement text, possibly empty.
+	Replacement string
+}
+
+// Transliterator replaces characters which could not be represented by an encoding,
+// e.g. to keep message in GSM 7-bit instead of UCS2.
+//
+// Transliterator is safe for concurrent use.
+type Transliterator struct {
+	table map[rune]string
+}
+
+// NewTransliterator returns Transliterator using given mapping tables.
+// Mappings of latter tables override former ones.
+func NewTransliterator(tables ...map[rune]string) *Transliterator {
+	t := &Transliterator{table: make(map[rune]string)}
+	for _, table := range tables {
+		for r, replacement := range table {
+			t.table[r] = replacement
+		}
+	}
+	return t
+}
+
+// DefaultTransliterator replaces typographic punctuation and Latin letters with diacritics.
+var DefaultTransliterator = NewTransliterator(TransliterationPunctuation, TransliterationLatin)
+
+// Transliterate replaces characters of text which could not be encoded by enc, if the table has
+// an encodable replacement for them. Other characters are kept as is.
+//
+// Returns the transliterated text and replaced characters.
+func (t *Transliterator) Transliterate(text string, enc Encoding) (result string, replaced []Replacement) {
+	if enc == nil {
+		enc = GSM7BIT
+	}
+
+	var (
+		runes []rune
+		index int
+	)
+	for _, r := range text {
+		if replacement, ok := t.table[r]; ok && !canEncode(enc, string(r)) && canEncode(enc, replacement) {
+			runes = append(runes, []rune(replacement)...)
+			replaced = append(replaced, Replacement{
+				Index:       index,
+				Original:    r,
+				Replacement: replacement,
+			})
+		} else {
+			runes = append(runes, r)
+		}
+		index++
+	}
+
+	if len(replaced) == 0 {
+		return text, nil
+	}
+	return string(runes), replaced
+}
+
+func canEncode(enc Encoding, s string) bool {
+	if s == "" {
+		return true
+	}
+	_, err := enc.Encode(s)
+	return err == nil
+}
+
+// TransliterationPunctuation maps typographic punctuation and symbols to ASCII.
+var TransliterationPunctuation = map[rune]string{
+	'\u00a0': " ", // no-break space
+	'\u2002': " ", '\u2003': " ", '\u2009': " ", '\u200a': " ", '\u202f': " ",
+	'\u200b': "", '\u200c': "", '\u200d': "", '\ufeff': "", // zero width characters
+	'\t': " ",
+
+	'‘': "'", '’': "'", '‚': "'", '‛': "'", '′': "'", '‹': "'", '›': "'",
+	'´': "'", '`': "'",
+	'“': "\"", '”': "\"", '„': "\"", '‟': "\"", '″': "\"", '«': "\"", '»': "\"",
+	'‐': "-", '‑': "-", '‒': "-", '–': "-", '—': "-", '―': "-", '−': "-",
+	'…': "...",
+	'•': "*", '·': ".",
+	'⁄': "/", '×': "x", '÷': "/",
+	'©': "(C)", '®': "(R)", '™': "TM",
+	'°': "o",
+	'¢': "c",
+	'₹': "Rs",
+}
+
+// TransliterationLatin maps Latin letters with diacritics to base letters.
+var TransliterationLatin = map[rune]string{
+	'À': "A", 'Á': "A", 'Â': "A", 'Ã': "A", 'Ā': "A", 'Ă': "A", 'Ą': "A",
+	'á': "a", 'â': "a", 'ã': "a", 'ā': "a", 'ă': "a", 'ą': "a",
+	'Ć': "C", 'Ĉ': "C", 'Ċ': "C", 'Č': "C",
+	'ç': "c", 'ć': "c", 'ĉ': "c", 'ċ': "c", 'č': "c",
+	'Ď': "D", 'Đ': "D", 'Ð': "D", 'ď': "d", 'đ': "d", 'ð': "d",
+	'È': "E", 'Ê': "E", 'Ë': "E", 'Ē': "E", 'Ĕ': "E", 'Ė': "E", 'Ę': "E", 'Ě': "E",
+	'ê': "e", 'ë': "e", 'ē': "e", 'ĕ': "e", 'ė': "e", 'ę': "e", 'ě': "e",
+	'Ĝ': "G", 'Ğ': "G", 'Ġ': "G", 'Ģ': "G", 'ĝ': "g", 'ğ': "g", 'ġ': "g", 'ģ': "g",
+	'Ĥ': "H", 'Ħ': "H", 'ĥ': "h", 'ħ': "h",
+	'Ì': "I", 'Í': "I", 'Î': "I", 'Ï': "I", 'Ĩ': "I", 'Ī': "I", 'Ĭ': "I", 'Į': "I", 'İ': "I",
+	'í': "i", 'î': "i", 'ï': "i", 'ĩ': "i", 'ī': "i", 'ĭ': "i", 'į': "i", 'ı': "i",
+	'Ĵ': "J", 'ĵ': "j",
+	'Ķ': "K", 'ķ': "k",
+	'Ĺ': "L", 'Ļ': "L", 'Ľ': "L", 'Ŀ': "L", 'Ł': "L", 'ĺ': "l", 'ļ': "l", 'ľ': "l", 'ŀ': "l", 'ł': "l",
+	'Ń': "N", 'Ņ': "N", 'Ň': "N", 'ń': "n", 'ņ': "n", 'ň': "n",
+	'Ò': "O", 'Ó': "O", 'Ô': "O", 'Õ': "O", 'Ō': "O", 'Ŏ': "O", 'Ő': "O",
+	'ó': "o", 'ô': "o", 'õ': "o", 'ō': "o", 'ŏ': "o", 'ő': "o",
+	'Œ': "OE", 'œ': "oe",
+	'Ŕ': "R", 'Ŗ': "R", 'Ř': "R", 'ŕ': "r", 'ŗ': "r", 'ř': "r",
+	'Ś': "S", 'Ŝ': "S", 'Ş': "S", 'Š': "S", 'Ș': "S", 'ś': "s", 'ŝ': "s", 'ş': "s", 'š': "s", 'ș': "s",
+	'Ţ': "T", 'Ť': "T", 'Ŧ': "T", 'Ț': "T", 'ţ': "t", 'ť': "t", 'ŧ': "t", 'ț': "t",
+	'Þ': "TH", 'þ': "th",
+	'Ù': "U", 'Ú': "U", 'Û': "U", 'Ũ': "U", 'Ū': "U", 'Ŭ': "U", 'Ů': "U", 'Ű': "U", 'Ų': "U",
+	'ú': "u", 'û': "u", 'ũ': "u", 'ū': "u", 'ŭ': "u", 'ů': "u", 'ű': "u", 'ų': "u",
+	'Ŵ': "W", 'ŵ': "w",
+	'Ý': "Y", 'Ŷ': "Y", 'Ÿ': "Y", 'ý': "y", 'ÿ': "y", 'ŷ': "y",
+	'Ź': "Z", 'Ż': "Z", 'Ž': "Z", 'ź': "z", 'ż': "z", 'ž': "z",
+}
+
+// TransliterationCyrillic maps Russian and Ukrainian Cyrillic letters to Latin.
+var TransliterationCyrillic = map[rune]string{
+	'А': "A", 'Б': "B", 'В': "V", 'Г': "G", 'Д': "D", 'Е': "E", 'Ё': "Yo", 'Ж': "Zh",
+	'З': "Z", 'И': "I", 'Й': "Y", 'К': "K", 'Л': "L", 'М': "M", 'Н': "N", 'О': "O",
+	'П': "P", 'Р': "R", 'С': "S", 'Т': "T", 'У': "U", 'Ф': "F", 'Х': "Kh", 'Ц': "Ts",
+	'Ч': "Ch", 'Ш': "Sh", 'Щ': "Shch", 'Ъ': "", 'Ы': "Y", 'Ь': "", 'Э': "E", 'Ю': "Yu", 'Я': "Ya",
+	'Є': "Ye", 'І': "I", 'Ї': "Yi", 'Ґ': "G",
+	'а': "a", 'б': "b", 'в': "v", 'г': "g", 'д': "d", 'е': "e", 'ё': "yo", 'ж': "zh",
+	'з': "z", 'и': "i", 'й': "y", 'к': "k", 'л': "l", 'м': "m", 'н': "n", 'о': "o",
+	'п': "p", 'р': "r", 'с': "s", 'т': "t", 'у': "u", 'ф': "f", 'х': "kh", 'ц': "ts",
+	'ч': "ch", 'ш': "sh", 'щ': "shch", 'ъ': "", 'ы': "y", 'ь': "", 'э': "e", 'ю': "yu", 'я': "ya",
+	'є': "ye", 'і': "i", 'ї': "yi", 'ґ': "g",
+}
+
+// TransliterationEmoji maps common emoji to emoticons.
+var TransliterationEmoji = map[rune]string{
+	'\ufe0f': "", // emoji presentation selector
+
+	'🙂': ":)", '😊': ":)", '😀': ":D", '😃': ":D", '😄': ":D", '😁': ":D", '😂': ":'D",
+	'😉': ";)", '😛': ":P", '😜': ";P", '🙁': ":(", '😞': ":(", '😢': ":'(", '😮': ":O",
+	'❤': "<3", '💔': "</3", '👍': "(y)", '👎': "(n)",
+}
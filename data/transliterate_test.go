@@ -0,0 +1,43 @@
+package data
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransliterator(t *testing.T) {
+	t.Run("default", func(t *testing.T) {
+		result, replaced := DefaultTransliterator.Transliterate("“Café” — naïve…", GSM7BIT)
+		require.Equal(t, "\"Café\" - naive...", result)
+		require.Equal(t, []Replacement{
+			{Index: 0, Original: '“', Replacement: "\""},
+			{Index: 5, Original: '”', Replacement: "\""},
+			{Index: 7, Original: '—', Replacement: "-"},
+			{Index: 11, Original: 'ï', Replacement: "i"},
+			{Index: 14, Original: '…', Replacement: "..."},
+		}, replaced)
+
+		// encodable characters are kept
+		result, replaced = DefaultTransliterator.Transliterate("“Café”", UCS2)
+		require.Equal(t, "“Café”", result)
+		require.Empty(t, replaced)
+
+		// characters without mapping are kept
+		result, replaced = DefaultTransliterator.Transliterate("hi 😀", GSM7BIT)
+		require.Equal(t, "hi 😀", result)
+		require.Empty(t, replaced)
+	})
+
+	t.Run("tables", func(t *testing.T) {
+		tr := NewTransliterator(TransliterationCyrillic, TransliterationEmoji, map[rune]string{'Щ': "Sch"})
+
+		result, replaced := tr.Transliterate("Привет, Щука 😀", GSM7BIT)
+		require.Equal(t, "Privet, Schuka :D", result)
+		require.Len(t, replaced, 11)
+
+		// Cyrillic is kept by encoding supporting it
+		result, _ = tr.Transliterate("Привет 😀", CYRILLIC)
+		require.Equal(t, "Привет :D", result)
+	})
+}
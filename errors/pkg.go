@@ -2,6 +2,7 @@ package errors
 
 import (
 	"fmt"
+	"math"
 
 	"github.com/linxGnu/gosmpp/data"
 )
@@ -32,4 +33,7 @@ var (
 
 	// ErrUDHTooLong UDH-L is larger than total length of short message data
 	ErrUDHTooLong = fmt.Errorf("User Data Header is too long for PDU short message")
+
+	// ErrTooManySegments indicates long message is split into more segments than concatenation could signal.
+	ErrTooManySegments = fmt.Errorf("Long message exceeds %d segments", math.MaxUint8)
 )
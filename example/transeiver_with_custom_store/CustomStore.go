@@ -131,6 +131,7 @@ func serialize(request gosmpp.Request) ([]byte, error) {
 	err := e.Encode(requestGob{
 		Pdu:      buf.Bytes(),
 		TimeSent: time.Time{},
+		Retries:  request.Retries,
 	})
 	if err != nil {
 		return b.Bytes()[:], errors.New("serialization failed")
@@ -157,10 +158,12 @@ func deserialize(bRequest []byte) (request gosmpp.Request, err error) {
 	return gosmpp.Request{
 		PDU:      p,
 		TimeSent: r.TimeSent,
+		Retries:  r.Retries,
 	}, nil
 }
 
 type requestGob struct {
 	Pdu      []byte
 	TimeSent time.Time
+	Retries  int
 }
@@ -0,0 +1,275 @@
+package gosmpp
+
+import (
+	"errors"
+	"math/rand/v2"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/linxGnu/gosmpp/pdu"
+)
+
+var (
+	// ErrNoEndpoint indicates FailoverSettings has no endpoint.
+	ErrNoEndpoint = errors.New("failover connector requires at least one endpoint")
+)
+
+// Endpoint is an SMSC address of FailoverConnector.
+type Endpoint struct {
+	// Address is SMSC address, replacing Auth.SMSC.
+	Address string
+
+	// Weight is relative share of binds among healthy endpoints, used when FailoverSettings.Weighted is set.
+	//
+	// Zero value is treated as 1.
+	Weight int
+}
+
+// EndpointHealth represents health of an endpoint, tracked by FailoverConnector.
+type EndpointHealth struct {
+	Endpoint
+
+	// Healthy indicates that endpoint is not cooling down after a failed bind.
+	Healthy bool
+
+	// Failures is the number of consecutive failed binds.
+	Failures int
+
+	// LastError is error of the latest failed bind.
+	LastError error
+
+	// LastFailure is time of the latest failed bind.
+	LastFailure time.Time
+
+	// LastSuccess is time of the latest successful bind.
+	LastSuccess time.Time
+}
+
+// FailoverSettings for FailoverConnector.
+type FailoverSettings struct {
+	// Endpoints are SMSC addresses. Unless Weighted is set, they are tried in order,
+	// i.e. the first one is primary, the second one is secondary and so on.
+	Endpoints []Endpoint
+
+	// Weighted spreads binds among healthy endpoints proportionally to their weights,
+	// instead of preferring them in order.
+	Weighted bool
+
+	// Cooldown is duration an endpoint is skipped after a failed bind. Endpoints cooling down
+	// are still tried as last resort, when all healthy ones fail.
+	//
+	// Zero value is treated as 30 seconds.
+	Cooldown time.Duration
+
+	// FailbackInterval is interval at which Session, while bound to a secondary endpoint,
+	// checks whether a more preferred endpoint is reachable again, then rebinds to it.
+	//
+	// Zero value disables failback. Failback is not applied to weighted endpoints and
+	// requires auto-rebind of Session.
+	FailbackInterval time.Duration
+}
+
+const defaultFailoverCooldown = 30 * time.Second
+
+// FailoverConnector is a connector over multiple SMSC endpoints, which tries them in turn
+// until a bind succeeds.
+//
+// Endpoint used by a bind is returned by Session.Endpoint, i.e. within OnRebind.
+type FailoverConnector struct {
+	c        *connector
+	settings FailoverSettings
+
+	mu      sync.Mutex
+	health  []EndpointHealth
+	current int
+}
+
+// NewFailoverConnector returns a connector over given endpoints, which replace Auth.SMSC.
+func NewFailoverConnector(dialer Dialer, auth Auth, bindingType pdu.BindingType, settings FailoverSettings, opts ...connectorOption) (*FailoverConnector, error) {
+	if len(settings.Endpoints) == 0 {
+		return nil, ErrNoEndpoint
+	}
+	if settings.Cooldown <= 0 {
+		settings.Cooldown = defaultFailoverCooldown
+	}
+
+	c := &connector{
+		dialer:      dialer,
+		auth:        auth,
+		bindingType: bindingType,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	health := make([]EndpointHealth, len(settings.Endpoints))
+	for i, e := range settings.Endpoints {
+		if e.Weight <= 0 {
+			e.Weight = 1
+		}
+		health[i] = EndpointHealth{Endpoint: e, Healthy: true}
+	}
+
+	return &FailoverConnector{
+		c:        c,
+		settings: settings,
+		health:   health,
+		current:  -1,
+	}, nil
+}
+
+// GetBindType returns binding type.
+func (c *FailoverConnector) GetBindType() pdu.BindingType {
+	return c.c.bindingType
+}
+
+// Connect binds to the first available endpoint, in order of preference.
+// Error of the last tried endpoint is returned if all of them fail.
+func (c *FailoverConnector) Connect() (conn *Connection, err error) {
+	return c.connect(nil)
+}
+
+func (c *FailoverConnector) connect(onBinding func()) (conn *Connection, err error) {
+	for _, i := range c.candidates(time.Now()) {
+		addr := c.health[i].Address
+
+		conn, err = connect(c.c.dialer, addr, newBindRequest(c.c.auth, c.c.bindingType, c.c.addressRange, c.c.interfaceVersion), c.c.sequence, onBinding)
+		c.report(i, err, time.Now())
+		if err == nil {
+			return
+		}
+	}
+	return
+}
+
+// Health returns health of endpoints, in configured order.
+func (c *FailoverConnector) Health() []EndpointHealth {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	health := make([]EndpointHealth, len(c.health))
+	copy(health, c.health)
+	return health
+}
+
+// Current returns endpoint of the latest successful bind.
+func (c *FailoverConnector) Current() (e Endpoint, ok bool) {
+	c.mu.Lock()
+	if ok = c.current >= 0; ok {
+		e = c.health[c.current].Endpoint
+	}
+	c.mu.Unlock()
+	return
+}
+
+// candidates returns indexes of endpoints to try: healthy ones first, in order of preference,
+// then cooling down ones, least recently failed first.
+func (c *FailoverConnector) candidates(now time.Time) []int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	healthy := make([]int, 0, len(c.health))
+	var cooling []int
+	for i := range c.health {
+		h := &c.health[i]
+		if !h.Healthy && now.Sub(h.LastFailure) >= c.settings.Cooldown {
+			h.Healthy = true
+		}
+		if h.Healthy {
+			healthy = append(healthy, i)
+		} else {
+			cooling = append(cooling, i)
+		}
+	}
+
+	if c.settings.Weighted {
+		healthy = c.shuffle(healthy)
+	}
+
+	sort.SliceStable(cooling, func(i, j int) bool {
+		return c.health[cooling[i]].LastFailure.Before(c.health[cooling[j]].LastFailure)
+	})
+
+	return append(healthy, cooling...)
+}
+
+// shuffle orders endpoints randomly, proportionally to their weights.
+func (c *FailoverConnector) shuffle(indexes []int) []int {
+	ordered := make([]int, 0, len(indexes))
+	for len(indexes) > 0 {
+		total := 0
+		for _, i := range indexes {
+			total += c.health[i].Weight
+		}
+
+		pick, n := rand.IntN(total), 0
+		for ; n < len(indexes)-1; n++ {
+			if pick -= c.health[indexes[n]].Weight; pick < 0 {
+				break
+			}
+		}
+
+		ordered = append(ordered, indexes[n])
+		indexes = append(indexes[:n], indexes[n+1:]...)
+	}
+	return ordered
+}
+
+// report records result of a bind to endpoint.
+func (c *FailoverConnector) report(i int, err error, now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	h := &c.health[i]
+	if err != nil {
+		h.Healthy = false
+		h.Failures++
+		h.LastError = err
+		h.LastFailure = now
+		return
+	}
+
+	h.Healthy = true
+	h.Failures = 0
+	h.LastError = nil
+	h.LastSuccess = now
+	c.current = i
+}
+
+// failbackInterval returns interval of failback check, zero if disabled.
+func (c *FailoverConnector) failbackInterval() time.Duration {
+	if c.settings.Weighted {
+		return 0
+	}
+	return c.settings.FailbackInterval
+}
+
+// failback checks whether an endpoint more preferred than current one is reachable,
+// by dialing it without binding. Endpoints cooling down are skipped.
+func (c *FailoverConnector) failback() bool {
+	now := time.Now()
+
+	c.mu.Lock()
+	var addrs []string
+	for i := 0; i < c.current; i++ {
+		if h := c.health[i]; h.Healthy || now.Sub(h.LastFailure) >= c.settings.Cooldown {
+			addrs = append(addrs, h.Address)
+		}
+	}
+	c.mu.Unlock()
+
+	for _, addr := range addrs {
+		if conn, err := c.c.dialer(addr); err == nil {
+			_ = conn.Close()
+			return true
+		}
+	}
+	return false
+}
+
+// failbackConnector is implemented by connectors which prefer returning to their primary endpoint.
+type failbackConnector interface {
+	failbackInterval() time.Duration
+	failback() bool
+}
@@ -0,0 +1,160 @@
+package gosmpp
+
+import (
+	"net"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/linxGnu/gosmpp/data"
+	"github.com/linxGnu/gosmpp/pdu"
+	"github.com/stretchr/testify/require"
+)
+
+func newFailoverServerSettings() ServerSettings {
+	return ServerSettings{
+		SystemID: "smsc",
+		Authenticate: func(req *pdu.BindRequest) data.CommandStatusType {
+			return data.ESME_ROK
+		},
+		SessionSettings: func(session *ServerSession) Settings {
+			return Settings{ReadTimeout: 2 * time.Second}
+		},
+	}
+}
+
+// unusedAddr returns a local address which nobody listens on.
+func unusedAddr(t *testing.T) string {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.Nil(t, err)
+	addr := l.Addr().String()
+	require.Nil(t, l.Close())
+	return addr
+}
+
+func TestFailoverConnector(t *testing.T) {
+	auth := Auth{SystemID: "esme", Password: "secret"}
+
+	t.Run("NoEndpoint", func(t *testing.T) {
+		_, err := NewFailoverConnector(NonTLSDialer, auth, pdu.Transceiver, FailoverSettings{})
+		require.Equal(t, ErrNoEndpoint, err)
+	})
+
+	t.Run("Ordered", func(t *testing.T) {
+		server, addr := newTestServer(t, newFailoverServerSettings())
+		defer func() {
+			_ = server.Close()
+		}()
+		dead := unusedAddr(t)
+
+		c, err := NewFailoverConnector(NonTLSDialer, auth, pdu.Transceiver, FailoverSettings{
+			Endpoints: []Endpoint{{Address: dead}, {Address: addr}},
+		})
+		require.Nil(t, err)
+		require.Equal(t, pdu.Transceiver, c.GetBindType())
+
+		conn, err := c.Connect()
+		require.Nil(t, err)
+		require.Equal(t, addr, conn.endpoint)
+		_ = conn.Close()
+
+		current, ok := c.Current()
+		require.True(t, ok)
+		require.Equal(t, addr, current.Address)
+
+		health := c.Health()
+		require.False(t, health[0].Healthy)
+		require.Equal(t, 1, health[0].Failures)
+		require.NotNil(t, health[0].LastError)
+		require.True(t, health[1].Healthy)
+		require.False(t, health[1].LastSuccess.IsZero())
+
+		// primary is cooling down, secondary is tried first
+		require.Equal(t, []int{1, 0}, c.candidates(time.Now()))
+		require.Equal(t, []int{0, 1}, c.candidates(time.Now().Add(defaultFailoverCooldown)))
+	})
+
+	t.Run("AllFailed", func(t *testing.T) {
+		c, err := NewFailoverConnector(NonTLSDialer, auth, pdu.Transceiver, FailoverSettings{
+			Endpoints: []Endpoint{{Address: unusedAddr(t)}, {Address: unusedAddr(t)}},
+		})
+		require.Nil(t, err)
+
+		_, err = c.Connect()
+		require.NotNil(t, err)
+
+		_, ok := c.Current()
+		require.False(t, ok)
+		for _, h := range c.Health() {
+			require.False(t, h.Healthy)
+		}
+	})
+
+	t.Run("Weighted", func(t *testing.T) {
+		c, err := NewFailoverConnector(NonTLSDialer, auth, pdu.Transceiver, FailoverSettings{
+			Endpoints: []Endpoint{{Address: "a", Weight: 1}, {Address: "b", Weight: 9}},
+			Weighted:  true,
+		})
+		require.Nil(t, err)
+
+		var first [2]int
+		for i := 0; i < 1000; i++ {
+			candidates := c.candidates(time.Now())
+			require.ElementsMatch(t, []int{0, 1}, candidates)
+			first[candidates[0]]++
+		}
+		require.Greater(t, first[1], first[0])
+		require.Greater(t, first[0], 0)
+	})
+}
+
+func TestSessionFailback(t *testing.T) {
+	secondary, secondaryAddr := newTestServer(t, newFailoverServerSettings())
+	defer func() {
+		_ = secondary.Close()
+	}()
+	primaryAddr := unusedAddr(t)
+
+	c, err := NewFailoverConnector(NonTLSDialer, Auth{SystemID: "esme", Password: "secret"}, pdu.Transceiver, FailoverSettings{
+		Endpoints:        []Endpoint{{Address: primaryAddr}, {Address: secondaryAddr}},
+		Cooldown:         50 * time.Millisecond,
+		FailbackInterval: 50 * time.Millisecond,
+	})
+	require.Nil(t, err)
+
+	var rebound atomic.Value
+	var session *Session
+	session, err = NewSession(c, Settings{
+		ReadTimeout: 2 * time.Second,
+		OnRebind: func() {
+			rebound.Store(session.Endpoint())
+		},
+	}, 100*time.Millisecond)
+	require.Nil(t, err)
+	defer func() {
+		_ = session.Close()
+	}()
+	require.Equal(t, secondaryAddr, session.Endpoint())
+
+	// primary recovers
+	primary, err := NewServer(newFailoverServerSettings())
+	require.Nil(t, err)
+	l, err := net.Listen("tcp", primaryAddr)
+	require.Nil(t, err)
+	go func() {
+		_ = primary.Serve(l)
+	}()
+	defer func() {
+		_ = primary.Close()
+	}()
+
+	require.Eventually(t, func() bool {
+		endpoint, _ := rebound.Load().(string)
+		return endpoint == primaryAddr
+	}, 2*time.Second, 10*time.Millisecond)
+	require.Equal(t, primaryAddr, session.Endpoint())
+
+	require.Eventually(t, func() bool {
+		return len(secondary.Sessions()) == 0 && len(primary.Sessions()) == 1
+	}, 2*time.Second, 10*time.Millisecond)
+}
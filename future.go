@@ -0,0 +1,183 @@
+package gosmpp
+
+import (
+	"context"
+	"sync"
+
+	"github.com/linxGnu/gosmpp/pdu"
+)
+
+// ResponseFuture represents the pending response of a submitted PDU.
+//
+// Response is correlated with the request by sequence number.
+// Correlated response is consumed by the future and not forwarded to
+// OnPDU, OnAllPDU or OnExpectedPduResponse callbacks.
+type ResponseFuture struct {
+	request pdu.PDU
+	pending *pendingResponses
+
+	once sync.Once
+	done chan struct{}
+	resp pdu.PDU
+	err  error
+}
+
+func newResponseFuture(request pdu.PDU, pending *pendingResponses) *ResponseFuture {
+	return &ResponseFuture{
+		request: request,
+		pending: pending,
+		done:    make(chan struct{}),
+	}
+}
+
+// Request returns submitted PDU.
+func (f *ResponseFuture) Request() pdu.PDU {
+	return f.request
+}
+
+// Done returns a channel which is closed when response comes or submitting failed.
+func (f *ResponseFuture) Done() <-chan struct{} {
+	return f.done
+}
+
+// Wait blocks until response comes, submitting failed or context is done.
+//
+// Returned PDU could be the expected response (i.e. SubmitSMResp) or GenericNack,
+// command status should be checked by caller.
+//
+// If context is done first, the future is abandoned: late response is dropped.
+func (f *ResponseFuture) Wait(ctx context.Context) (pdu.PDU, error) {
+	select {
+	case <-f.done:
+		return f.resp, f.err
+
+	case <-ctx.Done():
+		f.pending.remove(f)
+		f.complete(nil, ctx.Err())
+		return f.resp, f.err
+	}
+}
+
+func (f *ResponseFuture) complete(resp pdu.PDU, err error) {
+	f.once.Do(func() {
+		f.resp, f.err = resp, err
+		close(f.done)
+	})
+}
+
+// pendingResponses tracks futures waiting for responses.
+//
+// Sequence number is assigned when request is written, so futures are tracked
+// by request until then and by sequence number afterward.
+type pendingResponses struct {
+	mu      sync.Mutex
+	unsent  map[pdu.PDU]*ResponseFuture
+	futures map[int32]*ResponseFuture
+}
+
+func newPendingResponses() *pendingResponses {
+	return &pendingResponses{
+		unsent:  make(map[pdu.PDU]*ResponseFuture),
+		futures: make(map[int32]*ResponseFuture),
+	}
+}
+
+func (p *pendingResponses) add(request pdu.PDU) (f *ResponseFuture) {
+	f = newResponseFuture(request, p)
+	p.mu.Lock()
+	p.unsent[request] = f
+	p.mu.Unlock()
+	return
+}
+
+// sent tracks future of given request by its assigned sequence number.
+func (p *pendingResponses) sent(request pdu.PDU) {
+	p.mu.Lock()
+	if f := p.unsent[request]; f != nil {
+		delete(p.unsent, request)
+		p.futures[request.GetSequenceNumber()] = f
+	}
+	p.mu.Unlock()
+}
+
+func (p *pendingResponses) remove(f *ResponseFuture) {
+	p.mu.Lock()
+	if p.unsent[f.request] == f {
+		delete(p.unsent, f.request)
+	}
+	if seq := f.request.GetSequenceNumber(); p.futures[seq] == f {
+		delete(p.futures, seq)
+	}
+	p.mu.Unlock()
+}
+
+// len returns the number of futures waiting for responses.
+func (p *pendingResponses) len() (n int) {
+	p.mu.Lock()
+	n = len(p.unsent) + len(p.futures)
+	p.mu.Unlock()
+	return
+}
+
+// resolve completes future waiting for given response. Returns false if there is no such future.
+func (p *pendingResponses) resolve(resp pdu.PDU) bool {
+	p.mu.Lock()
+	f := p.futures[resp.GetSequenceNumber()]
+	delete(p.futures, resp.GetSequenceNumber())
+	p.mu.Unlock()
+
+	if f != nil {
+		f.complete(resp, nil)
+		return true
+	}
+	return false
+}
+
+// requeue tracks future waiting for given response by its request again, until the request
+// is re-submitted with a new sequence number.
+func (p *pendingResponses) requeue(resp pdu.PDU) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	f := p.futures[resp.GetSequenceNumber()]
+	if f == nil {
+		return false
+	}
+	delete(p.futures, resp.GetSequenceNumber())
+	p.unsent[f.request] = f
+	return true
+}
+
+// fail completes future of given request with error.
+func (p *pendingResponses) fail(request pdu.PDU, err error) {
+	p.mu.Lock()
+	f := p.unsent[request]
+	if f != nil {
+		delete(p.unsent, request)
+	} else if f = p.futures[request.GetSequenceNumber()]; f != nil && f.request == request {
+		delete(p.futures, request.GetSequenceNumber())
+	} else {
+		f = nil
+	}
+	p.mu.Unlock()
+
+	if f != nil {
+		f.complete(nil, err)
+	}
+}
+
+// failAll completes all pending futures with error.
+func (p *pendingResponses) failAll(err error) {
+	p.mu.Lock()
+	unsent, futures := p.unsent, p.futures
+	p.unsent = make(map[pdu.PDU]*ResponseFuture)
+	p.futures = make(map[int32]*ResponseFuture)
+	p.mu.Unlock()
+
+	for _, f := range unsent {
+		f.complete(nil, err)
+	}
+	for _, f := range futures {
+		f.complete(nil, err)
+	}
+}
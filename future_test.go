@@ -0,0 +1,144 @@
+package gosmpp
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/linxGnu/gosmpp/data"
+	"github.com/linxGnu/gosmpp/pdu"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPendingResponses(t *testing.T) {
+	pending := newPendingResponses()
+
+	req := pdu.NewSubmitSM()
+	req.SetSequenceNumber(7)
+	f := pending.add(req)
+
+	// not written yet
+	resp := req.GetResponse()
+	require.False(t, pending.resolve(resp))
+
+	pending.sent(req)
+
+	// response of another request
+	require.False(t, pending.resolve(pdu.NewSubmitSMResp()))
+
+	require.True(t, pending.resolve(resp))
+	require.False(t, pending.resolve(resp))
+
+	got, err := f.Wait(context.Background())
+	require.Nil(t, err)
+	require.Equal(t, resp, got)
+
+	// failing
+	req = pdu.NewSubmitSM()
+	f = pending.add(req)
+	pending.failAll(ErrConnectionClosing)
+	<-f.Done()
+	_, err = f.Wait(context.Background())
+	require.ErrorIs(t, err, ErrConnectionClosing)
+}
+
+func TestSubmitAndWait(t *testing.T) {
+	server, addr := newTestServer(t, ServerSettings{
+		SessionSettings: func(*ServerSession) Settings {
+			return Settings{
+				ReadTimeout: 2 * time.Second,
+				OnAllPDU: func(p pdu.PDU) (pdu.PDU, bool) {
+					switch pd := p.(type) {
+					case *pdu.SubmitSM:
+						msg, _ := pd.Message.GetMessage()
+						if msg == "drop" {
+							return nil, false
+						}
+						resp := pd.GetResponse().(*pdu.SubmitSMResp)
+						resp.MessageID = msg
+						return resp, false
+
+					case *pdu.QuerySM:
+						nack := pdu.NewGenericNack().(*pdu.GenericNack)
+						nack.CommandStatus = data.ESME_RINVCMDID
+						nack.SetSequenceNumber(pd.GetSequenceNumber())
+						return nack, false
+					}
+					return nil, false
+				},
+			}
+		},
+	})
+	defer func() {
+		_ = server.Close()
+	}()
+
+	session, err := NewSession(
+		TRXConnector(NonTLSDialer, Auth{SMSC: addr, SystemID: "esme"}),
+		Settings{
+			ReadTimeout: 2 * time.Second,
+			OnPDU: func(p pdu.PDU, _ bool) {
+				if _, ok := p.(*pdu.SubmitSMResp); ok {
+					t.Errorf("awaited response must not be forwarded to OnPDU: %+v", p)
+				}
+			},
+		}, -1)
+	require.Nil(t, err)
+	defer func() {
+		_ = session.Close()
+	}()
+
+	newSubmit := func(msg string) *pdu.SubmitSM {
+		p := pdu.NewSubmitSM().(*pdu.SubmitSM)
+		_ = p.Message.SetMessageWithEncoding(msg, data.GSM7BIT)
+		return p
+	}
+
+	t.Run("Response", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+
+		req := newSubmit("hello")
+		resp, err := session.SubmitAndWait(ctx, req)
+		require.Nil(t, err)
+		require.IsType(t, &pdu.SubmitSMResp{}, resp)
+		require.Equal(t, req.GetSequenceNumber(), resp.GetSequenceNumber())
+		require.Equal(t, "hello", resp.(*pdu.SubmitSMResp).MessageID)
+	})
+
+	t.Run("Async", func(t *testing.T) {
+		futures := make([]*ResponseFuture, 0, 10)
+		for i := 0; i < 10; i++ {
+			f, err := session.SubmitAsync(newSubmit(string(rune('a' + i))))
+			require.Nil(t, err)
+			futures = append(futures, f)
+		}
+
+		for i, f := range futures {
+			resp, err := f.Wait(context.Background())
+			require.Nil(t, err)
+			require.Equal(t, string(rune('a'+i)), resp.(*pdu.SubmitSMResp).MessageID)
+		}
+	})
+
+	t.Run("GenericNack", func(t *testing.T) {
+		resp, err := session.SubmitAndWait(context.Background(), pdu.NewQuerySM())
+		require.Nil(t, err)
+		require.True(t, resp.IsGNack())
+		require.Equal(t, data.ESME_RINVCMDID, resp.GetHeader().CommandStatus)
+	})
+
+	t.Run("Deadline", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+
+		_, err := session.SubmitAndWait(ctx, newSubmit("drop"))
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+
+	t.Run("NotRespondable", func(t *testing.T) {
+		_, err := session.SubmitAsync(pdu.NewSubmitSMResp())
+		require.ErrorIs(t, err, ErrPDUNotRespondable)
+	})
+}
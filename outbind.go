@@ -0,0 +1,275 @@
+package gosmpp
+
+import (
+	"errors"
+	"net"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/linxGnu/gosmpp/data"
+	"github.com/linxGnu/gosmpp/pdu"
+)
+
+var (
+	// ErrOutbindListenerClosed is returned by OutbindListener.Serve after OutbindListener.Close is called.
+	ErrOutbindListenerClosed = errors.New("outbind listener closed")
+
+	// ErrOnSessionNotSet indicates OutbindSettings.OnSession is missing.
+	ErrOnSessionNotSet = errors.New("OnSession cannot be nil")
+
+	// ErrInvalidOutbind indicates that outbind sent by SMSC is rejected, i.e. its system_id
+	// or password is invalid.
+	ErrInvalidOutbind = errors.New("invalid outbind system_id or password")
+
+	// ErrOutbindRebind is returned when session created from outbind tries to rebind,
+	// since only SMSC could initiate connection again.
+	ErrOutbindRebind = errors.New("session created from outbind cannot rebind")
+)
+
+// OutbindAuthenticator validates outbind sent by SMSC.
+type OutbindAuthenticator func(outbind *pdu.Outbind) bool
+
+// OutbindSettings for OutbindListener.
+type OutbindSettings struct {
+	// Auth is used for bind_receiver sent back to SMSC. Auth.SMSC is ignored.
+	Auth Auth
+
+	// Authenticate validates outbind.
+	//
+	// If not set, outbind is accepted when its system_id and password equal to Auth.
+	Authenticate OutbindAuthenticator
+
+	// BindTimeout is the maximum duration OutbindListener waits for outbind on a newly accepted
+	// connection, then for bind_resp.
+	//
+	// Zero duration disables the timeout.
+	BindTimeout time.Duration
+
+	// ConnectorOptions apply to bind_receiver, e.g. WithAddressRange or WithInterfaceVersion.
+	ConnectorOptions []connectorOption
+
+	// Settings returns Settings for the session created from outbind.
+	// The returned Settings are validated the same way NewSession does.
+	Settings func(outbind *pdu.Outbind) Settings
+
+	// OnSession receives session created from outbind. Session is owned by the receiver,
+	// which is responsible for closing it.
+	//
+	// Session does not rebind, SMSC is expected to outbind again instead.
+	OnSession func(*Session)
+
+	// OnAcceptError notifies error while accepting new connection.
+	OnAcceptError ErrorCallback
+
+	// OnBindError notifies error while waiting or validating outbind, or binding.
+	OnBindError ErrorCallback
+}
+
+// OutbindListener accepts connections initiated by SMSC(s) with outbind, then binds
+// as receiver over the same connections.
+type OutbindListener struct {
+	settings OutbindSettings
+
+	mu        sync.Mutex
+	listeners map[net.Listener]struct{}
+
+	state int32
+}
+
+// NewOutbindListener creates new listener for outbind(s).
+func NewOutbindListener(settings OutbindSettings) (*OutbindListener, error) {
+	if settings.OnSession == nil {
+		return nil, ErrOnSessionNotSet
+	}
+	if settings.Settings == nil {
+		return nil, ErrSessionSettingsNotSet
+	}
+
+	return &OutbindListener{
+		settings:  settings,
+		listeners: make(map[net.Listener]struct{}),
+	}, nil
+}
+
+// ListenAndServe listens on the TCP network address and then calls Serve.
+func (l *OutbindListener) ListenAndServe(addr string) error {
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+	return l.Serve(ln)
+}
+
+// Serve accepts incoming connections on the listener, handling outbind and creating
+// a receiver session for each of them.
+//
+// Serve always returns non-nil error and closes the listener.
+// After OutbindListener.Close, the returned error is ErrOutbindListenerClosed.
+func (l *OutbindListener) Serve(ln net.Listener) error {
+	if !l.trackListener(ln, true) {
+		_ = ln.Close()
+		return ErrOutbindListenerClosed
+	}
+	defer l.trackListener(ln, false)
+
+	for {
+		conn, err := ln.Accept()
+		if err != nil {
+			if atomic.LoadInt32(&l.state) == Closed {
+				return ErrOutbindListenerClosed
+			}
+
+			if l.settings.OnAcceptError != nil {
+				l.settings.OnAcceptError(err)
+			}
+
+			var nErr net.Error
+			if errors.As(err, &nErr) && nErr.Timeout() {
+				time.Sleep(5 * time.Millisecond)
+				continue
+			}
+
+			_ = ln.Close()
+			return err
+		}
+
+		go l.handle(conn)
+	}
+}
+
+// Close listener: stop all listeners. Sessions created from outbind are not closed.
+func (l *OutbindListener) Close() (err error) {
+	if atomic.CompareAndSwapInt32(&l.state, Alive, Closed) {
+		l.mu.Lock()
+		for ln := range l.listeners {
+			if e := ln.Close(); e != nil && err == nil {
+				err = e
+			}
+		}
+		l.mu.Unlock()
+	}
+	return
+}
+
+func (l *OutbindListener) trackListener(ln net.Listener, add bool) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if add {
+		if atomic.LoadInt32(&l.state) == Closed {
+			return false
+		}
+		l.listeners[ln] = struct{}{}
+	} else {
+		delete(l.listeners, ln)
+	}
+	return true
+}
+
+func (l *OutbindListener) handle(netConn net.Conn) {
+	conn := NewConnection(netConn)
+	conn.endpoint = netConn.RemoteAddr().String()
+
+	session, err := l.bind(conn)
+	if err != nil {
+		if l.settings.OnBindError != nil {
+			l.settings.OnBindError(err)
+		}
+		_ = conn.Close()
+		return
+	}
+
+	l.settings.OnSession(session)
+}
+
+func (l *OutbindListener) bind(conn *Connection) (session *Session, err error) {
+	if l.settings.BindTimeout > 0 {
+		if err = conn.SetReadTimeout(l.settings.BindTimeout); err != nil {
+			return
+		}
+	}
+
+	outbind, err := waitOutbind(conn)
+	if err != nil {
+		return
+	}
+
+	if !l.authenticate(outbind) {
+		err = ErrInvalidOutbind
+		return
+	}
+
+	settings := l.settings.Settings(outbind)
+	if err = validateSettings(settings); err != nil {
+		return
+	}
+
+	c := &connector{
+		auth:        l.settings.Auth,
+		bindingType: pdu.Receiver,
+	}
+	for _, opt := range l.settings.ConnectorOptions {
+		opt(c)
+	}
+
+	if err = bind(conn, newBindRequest(c.auth, c.bindingType, c.addressRange, c.interfaceVersion), c.sequence); err != nil {
+		return
+	}
+
+	// reset read deadline used while binding
+	if err = conn.SetReadDeadline(time.Time{}); err != nil {
+		return
+	}
+
+	return NewSession(&outbindConnector{conn: conn}, settings, -1)
+}
+
+func (l *OutbindListener) authenticate(outbind *pdu.Outbind) bool {
+	if l.settings.Authenticate != nil {
+		return l.settings.Authenticate(outbind)
+	}
+	return outbind.SystemID == l.settings.Auth.SystemID && outbind.Password == l.settings.Auth.Password
+}
+
+// waitOutbind reads PDU(s) from connection until outbind comes.
+// Any other PDU is rejected with generic_nack(ESME_RINVBNDSTS).
+func waitOutbind(conn *Connection) (outbind *pdu.Outbind, err error) {
+	for {
+		var p pdu.PDU
+		if p, err = pdu.Parse(conn); err != nil {
+			return
+		}
+
+		if o, ok := p.(*pdu.Outbind); ok {
+			outbind = o
+			return
+		}
+
+		if p.CanResponse() {
+			nack := pdu.NewGenericNack().(*pdu.GenericNack)
+			nack.CommandStatus = data.ESME_RINVBNDSTS
+			nack.SetSequenceNumber(p.GetSequenceNumber())
+			if _, err = conn.WritePDU(nack); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// outbindConnector hands a connection bound after outbind to Session, once.
+type outbindConnector struct {
+	conn *Connection
+	used int32
+}
+
+func (c *outbindConnector) GetBindType() pdu.BindingType {
+	return pdu.Receiver
+}
+
+func (c *outbindConnector) Connect() (*Connection, error) {
+	if atomic.CompareAndSwapInt32(&c.used, 0, 1) {
+		return c.conn, nil
+	}
+	return nil, ErrOutbindRebind
+}
@@ -0,0 +1,111 @@
+package gosmpp
+
+import (
+	"net"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/linxGnu/gosmpp/data"
+	"github.com/linxGnu/gosmpp/pdu"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestOutbindListener(t *testing.T) {
+	_, err := NewOutbindListener(OutbindSettings{})
+	require.Equal(t, ErrOnSessionNotSet, err)
+
+	_, err = NewOutbindListener(OutbindSettings{OnSession: func(*Session) {}})
+	require.Equal(t, ErrSessionSettingsNotSet, err)
+
+	server, _ := newTestServer(t, ServerSettings{
+		SystemID: "smsc",
+		Authenticate: func(req *pdu.BindRequest) data.CommandStatusType {
+			if req.SystemID == "esme" && req.Password == "secret" {
+				return data.ESME_ROK
+			}
+			return data.ESME_RINVPASWD
+		},
+		SessionSettings: func(*ServerSession) Settings {
+			return Settings{ReadTimeout: 2 * time.Second}
+		},
+	})
+	defer func() {
+		_ = server.Close()
+	}()
+
+	var receivedDeliverSM int32
+	sessions := make(chan *Session, 1)
+	bindErrors := make(chan error, 1)
+
+	listener, err := NewOutbindListener(OutbindSettings{
+		Auth:        Auth{SystemID: "esme", Password: "secret"},
+		BindTimeout: time.Second,
+		Settings: func(outbind *pdu.Outbind) Settings {
+			require.Equal(t, "esme", outbind.SystemID)
+			return Settings{
+				ReadTimeout: 2 * time.Second,
+				OnPDU: func(p pdu.PDU, responded bool) {
+					if _, ok := p.(*pdu.DeliverSM); ok {
+						require.True(t, responded)
+						atomic.AddInt32(&receivedDeliverSM, 1)
+					}
+				},
+			}
+		},
+		OnSession: func(session *Session) {
+			sessions <- session
+		},
+		OnBindError: func(err error) {
+			bindErrors <- err
+		},
+	})
+	require.Nil(t, err)
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.Nil(t, err)
+	go func() {
+		_ = listener.Serve(l)
+	}()
+	defer func() {
+		_ = listener.Close()
+	}()
+
+	t.Run("InvalidOutbind", func(t *testing.T) {
+		require.Nil(t, server.Outbind(NonTLSDialer, l.Addr().String(), "esme", "wrong"))
+
+		select {
+		case err := <-bindErrors:
+			require.Equal(t, ErrInvalidOutbind, err)
+		case <-time.After(2 * time.Second):
+			t.Fatal("outbind was not rejected")
+		}
+	})
+
+	t.Run("Bind", func(t *testing.T) {
+		require.Nil(t, server.Outbind(NonTLSDialer, l.Addr().String(), "esme", "secret"))
+
+		var session *Session
+		select {
+		case session = <-sessions:
+		case <-time.After(2 * time.Second):
+			t.Fatal("session was not created")
+		}
+		defer func() {
+			_ = session.Close()
+		}()
+		require.Equal(t, "smsc", session.Receiver().SystemID())
+
+		require.Eventually(t, func() bool {
+			return len(server.Sessions()) == 1
+		}, time.Second, 10*time.Millisecond)
+		serverSession := server.Sessions()[0]
+		require.Equal(t, pdu.Receiver, serverSession.BindingType())
+
+		require.Nil(t, serverSession.Submit(pdu.NewDeliverSM()))
+		require.Eventually(t, func() bool {
+			return atomic.LoadInt32(&receivedDeliverSM) == 1
+		}, time.Second, 10*time.Millisecond)
+	})
+}
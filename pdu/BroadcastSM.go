@@ -0,0 +1,150 @@
+package pdu
+
+import (
+	"github.com/linxGnu/gosmpp/data"
+)
+
+// BroadcastSM PDU is issued by the ESME to submit a message to the Message Centre for broadcast to
+// a specified geographical area or set of geographical areas, as defined by SMPP 5.0.
+//
+// Message content is carried within message_payload TLV. Mandatory broadcast_content_type,
+// broadcast_rep_num and broadcast_frequency_interval TLVs must be registered as optional parameters.
+type BroadcastSM struct {
+	base
+	ServiceType          string
+	SourceAddr           Address
+	MessageID            string // should be NULL, used for replacing broadcast
+	PriorityFlag         byte
+	ScheduleDeliveryTime SMPPTime
+	ValidityPeriod       SMPPTime
+	ReplaceIfPresentFlag byte
+	DataCoding           byte
+	SmDefaultMsgID       byte
+
+	// AreaIdentifiers is list of broadcast_area_identifier TLVs, at least one is mandatory.
+	AreaIdentifiers []BroadcastAreaIdentifier
+}
+
+// NewBroadcastSM returns BroadcastSM PDU.
+func NewBroadcastSM() PDU {
+	c := &BroadcastSM{
+		base:                 newBase(),
+		ServiceType:          data.DFLT_SRVTYPE,
+		SourceAddr:           NewAddress(),
+		MessageID:            data.DFLT_MSGID,
+		PriorityFlag:         data.DFLT_PRIORITY_FLAG,
+		ReplaceIfPresentFlag: data.DFTL_REPLACE_IFP,
+		DataCoding:           data.DFLT_DATA_CODING,
+		SmDefaultMsgID:       data.DFLT_DFLTMSGID,
+	}
+	c.CommandID = data.BROADCAST_SM
+	return c
+}
+
+// SetMessage encodes message into message_payload TLV and sets data_coding accordingly.
+func (c *BroadcastSM) SetMessage(message string, enc data.Encoding) (err error) {
+	if enc == nil {
+		enc = data.GSM7BIT
+	}
+
+	payload, err := enc.Encode(message)
+	if err == nil {
+		c.DataCoding = enc.DataCoding()
+		err = c.SetTLVOctetString(TagMessagePayload, payload)
+	}
+	return
+}
+
+// GetMessage decodes message_payload TLV according to data_coding.
+func (c *BroadcastSM) GetMessage() (string, error) {
+	payload, err := c.GetTLVOctetString(TagMessagePayload)
+	if err != nil {
+		return "", err
+	}
+	return data.FromDataCoding(c.DataCoding).Decode(payload)
+}
+
+// CanResponse implements PDU interface.
+func (c *BroadcastSM) CanResponse() bool {
+	return true
+}
+
+// GetResponse implements PDU interface.
+func (c *BroadcastSM) GetResponse() PDU {
+	return NewBroadcastSMRespFromReq(c)
+}
+
+// Marshal implements PDU interface.
+func (c *BroadcastSM) Marshal(b *ByteBuffer) {
+	c.base.marshal(b, func(b *ByteBuffer) {
+		b.Grow(len(c.ServiceType) + len(c.MessageID) + 6)
+
+		_ = b.WriteCString(c.ServiceType)
+		c.SourceAddr.Marshal(b)
+		_ = b.WriteCString(c.MessageID)
+		_ = b.WriteByte(c.PriorityFlag)
+		c.ScheduleDeliveryTime.Marshal(b)
+		c.ValidityPeriod.Marshal(b)
+		_ = b.WriteByte(c.ReplaceIfPresentFlag)
+		_ = b.WriteByte(c.DataCoding)
+		_ = b.WriteByte(c.SmDefaultMsgID)
+
+		marshalAreaIdentifiers(b, c.AreaIdentifiers)
+	})
+}
+
+// Unmarshal implements PDU interface.
+func (c *BroadcastSM) Unmarshal(b *ByteBuffer) error {
+	return c.base.unmarshal(b, func(b *ByteBuffer) (err error) {
+		bodyStart := b.Len()
+
+		if c.ServiceType, err = b.ReadCString(); err == nil {
+			if err = c.SourceAddr.Unmarshal(b); err == nil {
+				if c.MessageID, err = b.ReadCString(); err == nil {
+					if c.PriorityFlag, err = b.ReadByte(); err == nil {
+						if err = c.ScheduleDeliveryTime.Unmarshal(b); err == nil {
+							if err = c.ValidityPeriod.Unmarshal(b); err == nil {
+								if c.ReplaceIfPresentFlag, err = b.ReadByte(); err == nil {
+									if c.DataCoding, err = b.ReadByte(); err == nil {
+										if c.SmDefaultMsgID, err = b.ReadByte(); err == nil {
+											c.AreaIdentifiers, err = c.unmarshalAreaIdentifiers(b, bodyStart, nil)
+										}
+									}
+								}
+							}
+						}
+					}
+				}
+			}
+		}
+		return
+	})
+}
+
+func marshalAreaIdentifiers(b *ByteBuffer, areas []BroadcastAreaIdentifier) {
+	for i := range areas {
+		f := Field{Tag: TagBroadcastAreaIdentifier, Data: append([]byte{areas[i].Format}, areas[i].Details...)}
+		f.Marshal(b)
+	}
+}
+
+// unmarshalAreaIdentifiers reads remaining optional parameters, collecting broadcast_area_identifier(s)
+// and, if success is not nil, broadcast_area_success(es).
+func (c *base) unmarshalAreaIdentifiers(b *ByteBuffer, bodyStart int, success *[]byte) (areas []BroadcastAreaIdentifier, err error) {
+	err = c.unmarshalRepeatedParams(b, bodyStart, func(f Field) bool {
+		switch {
+		case f.Tag == TagBroadcastAreaIdentifier:
+			if area, e := f.BroadcastAreaIdentifier(); e == nil {
+				areas = append(areas, area)
+				return true
+			}
+		case f.Tag == TagBroadcastAreaSuccess && success != nil:
+			if rate, e := f.Uint8(); e == nil {
+				*success = append(*success, rate)
+				return true
+			}
+		}
+		return false
+	})
+	return
+}
@@ -0,0 +1,66 @@
+package pdu
+
+import (
+	"github.com/linxGnu/gosmpp/data"
+)
+
+// BroadcastSMResp PDU.
+type BroadcastSMResp struct {
+	base
+	MessageID string
+
+	// FailedAreaIdentifiers is list of failed_broadcast_area_identifier TLVs, indicating
+	// areas for which broadcast could not be accepted.
+	FailedAreaIdentifiers []BroadcastAreaIdentifier
+}
+
+// NewBroadcastSMResp returns BroadcastSMResp.
+func NewBroadcastSMResp() PDU {
+	c := &BroadcastSMResp{
+		base:      newBase(),
+		MessageID: data.DFLT_MSGID,
+	}
+	c.CommandID = data.BROADCAST_SM_RESP
+	return c
+}
+
+// NewBroadcastSMRespFromReq returns BroadcastSMResp.
+func NewBroadcastSMRespFromReq(req *BroadcastSM) PDU {
+	c := NewBroadcastSMResp().(*BroadcastSMResp)
+	if req != nil {
+		c.SequenceNumber = req.SequenceNumber
+	}
+	return c
+}
+
+// CanResponse implements PDU interface.
+func (c *BroadcastSMResp) CanResponse() bool {
+	return false
+}
+
+// GetResponse implements PDU interface.
+func (c *BroadcastSMResp) GetResponse() PDU {
+	return nil
+}
+
+// Marshal implements PDU interface.
+func (c *BroadcastSMResp) Marshal(b *ByteBuffer) {
+	c.base.marshal(b, func(b *ByteBuffer) {
+		b.Grow(len(c.MessageID) + 1)
+
+		_ = b.WriteCString(c.MessageID)
+		marshalAreaIdentifiers(b, c.FailedAreaIdentifiers)
+	})
+}
+
+// Unmarshal implements PDU interface.
+func (c *BroadcastSMResp) Unmarshal(b *ByteBuffer) error {
+	return c.base.unmarshal(b, func(b *ByteBuffer) (err error) {
+		bodyStart := b.Len()
+
+		if c.MessageID, err = b.ReadCString(); err == nil {
+			c.FailedAreaIdentifiers, err = c.unmarshalAreaIdentifiers(b, bodyStart, nil)
+		}
+		return
+	})
+}
@@ -0,0 +1,27 @@
+package pdu
+
+import (
+	"testing"
+
+	"github.com/linxGnu/gosmpp/data"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBroadcastSMResp(t *testing.T) {
+	req := NewBroadcastSM().(*BroadcastSM)
+	req.SequenceNumber = 13
+
+	v := NewBroadcastSMRespFromReq(req).(*BroadcastSMResp)
+	require.False(t, v.CanResponse())
+	require.Nil(t, v.GetResponse())
+
+	v.MessageID = "id"
+	v.FailedAreaIdentifiers = []BroadcastAreaIdentifier{{Format: 0x01, Details: []byte{0x01, 0x02}}}
+
+	validate(t,
+		v,
+		"0000001a80000111000000000000000d69640006060003010102",
+		data.BROADCAST_SM_RESP,
+	)
+}
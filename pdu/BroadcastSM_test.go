@@ -0,0 +1,60 @@
+package pdu
+
+import (
+	"testing"
+
+	"github.com/linxGnu/gosmpp/data"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBroadcastSM(t *testing.T) {
+	v := NewBroadcastSM().(*BroadcastSM)
+	require.True(t, v.CanResponse())
+	v.SequenceNumber = 13
+
+	validate(t,
+		v.GetResponse(),
+		"0000001180000111000000000000000d00",
+		data.BROADCAST_SM_RESP,
+	)
+
+	v.ServiceType = "abc"
+	_ = v.SourceAddr.SetAddress("Alicer")
+	v.SourceAddr.SetTon(28)
+	v.SourceAddr.SetNpi(29)
+	v.PriorityFlag = 1
+	v.AreaIdentifiers = []BroadcastAreaIdentifier{{Format: 0x00, Details: []byte("ab")}}
+	require.NoError(t, v.SetMessage("Hi", data.GSM7BIT))
+
+	validate(t,
+		v,
+		"0000003100000111000000000000000d616263001c1d416c69636572000001000000000006060003006162042400024869",
+		data.BROADCAST_SM,
+	)
+
+	message, err := v.GetMessage()
+	require.NoError(t, err)
+	require.Equal(t, "Hi", message)
+
+	// mandatory TLVs and multiple areas
+	contentType, err := NewTLVBroadcastContentType(BroadcastContentType{NetworkType: 1, ContentType: 0x0002})
+	require.NoError(t, err)
+	v.RegisterOptionalParam(contentType)
+
+	interval, err := NewTLVBroadcastFrequencyInterval(BroadcastFrequencyInterval{Unit: 0x09, Value: 5})
+	require.NoError(t, err)
+	v.RegisterOptionalParam(interval)
+
+	require.NoError(t, v.SetTLVUint16(TagBroadcastRepNum, 3))
+	require.NoError(t, v.SetMessage("Привет", data.UCS2))
+	v.AreaIdentifiers = append(v.AreaIdentifiers, BroadcastAreaIdentifier{Format: 0x00, Details: []byte("cd")})
+
+	b := NewBuffer(nil)
+	v.Marshal(b)
+	expectAfterParse(t, b, v, data.BROADCAST_SM)
+
+	message, err = v.GetMessage()
+	require.NoError(t, err)
+	require.Equal(t, "Привет", message)
+}
@@ -60,7 +60,7 @@ func (c *DeliverSM) Marshal(b *ByteBuffer) {
 		_ = b.WriteCString(c.ServiceType)
 		c.SourceAddr.Marshal(b)
 		c.DestAddr.Marshal(b)
-		_ = b.WriteByte(c.EsmClass)
+		_ = b.WriteByte(c.Message.esmClass(c.EsmClass))
 		_ = b.WriteByte(c.ProtocolID)
 		_ = b.WriteByte(c.PriorityFlag)
 		_ = b.WriteCString(c.ScheduleDeliveryTime)
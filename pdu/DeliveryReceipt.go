@@ -0,0 +1,300 @@
+package pdu
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/linxGnu/gosmpp/data"
+)
+
+var (
+	// ErrNotDeliveryReceipt indicates that PDU does not carry a delivery receipt.
+	ErrNotDeliveryReceipt = fmt.Errorf("PDU is not a delivery receipt")
+
+	// ErrInvalidDeliveryReceipt indicates that delivery receipt text could not be parsed.
+	ErrInvalidDeliveryReceipt = fmt.Errorf("invalid delivery receipt format")
+)
+
+// Delivery receipt final states (stat field).
+const (
+	DeliveryReceiptEnroute       = "ENROUTE"
+	DeliveryReceiptDelivered     = "DELIVRD"
+	DeliveryReceiptExpired       = "EXPIRED"
+	DeliveryReceiptDeleted       = "DELETED"
+	DeliveryReceiptUndeliverable = "UNDELIV"
+	DeliveryReceiptAccepted      = "ACCEPTD"
+	DeliveryReceiptUnknown       = "UNKNOWN"
+	DeliveryReceiptRejected      = "REJECTD"
+)
+
+var deliveryReceiptStates = map[string]byte{
+	DeliveryReceiptEnroute:       data.SM_STATE_EN_ROUTE,
+	DeliveryReceiptDelivered:     data.SM_STATE_DELIVERED,
+	DeliveryReceiptExpired:       data.SM_STATE_EXPIRED,
+	DeliveryReceiptDeleted:       data.SM_STATE_DELETED,
+	DeliveryReceiptUndeliverable: data.SM_STATE_UNDELIVERABLE,
+	DeliveryReceiptAccepted:      data.SM_STATE_ACCEPTED,
+	DeliveryReceiptUnknown:       data.SM_STATE_INVALID,
+	DeliveryReceiptRejected:      data.SM_STATE_REJECTED,
+}
+
+// vendor variations of final states
+var deliveryReceiptStatAliases = map[string]string{
+	"DELIVERED":     DeliveryReceiptDelivered,
+	"UNDELIVERED":   DeliveryReceiptUndeliverable,
+	"UNDELIVERABLE": DeliveryReceiptUndeliverable,
+	"ACCEPTED":      DeliveryReceiptAccepted,
+	"REJECTED":      DeliveryReceiptRejected,
+	"EN_ROUTE":      DeliveryReceiptEnroute,
+}
+
+// deliveryReceiptKeys matches fields of receipt text. Separators and letter case vary between vendors.
+var deliveryReceiptKeys = regexp.MustCompile(`(?i)\b(id|sub|dlvrd|submit[ _]?date|done[ _]?date|stat|err|text)\s*:`)
+
+var deliveryReceiptDateLayouts = []string{
+	"0601021504",     // YYMMDDhhmm, SMPP 3.4 Appendix B
+	"060102150405",   // YYMMDDhhmmss
+	"200601021504",   // YYYYMMDDhhmm
+	"20060102150405", // YYYYMMDDhhmmss
+}
+
+// NetworkErrorCode is value of network_error_code TLV.
+type NetworkErrorCode struct {
+	// NetworkType: 1 = ANSI-136, 2 = IS-95, 3 = GSM, ...
+	NetworkType byte
+	ErrorCode   uint16
+}
+
+// DeliveryReceipt represents SMSC delivery receipt, carried within short message text
+// of deliver_sm as described in SMPP 3.4 Appendix B:
+//
+//	id:IIIIIIIIII sub:SSS dlvrd:DDD submit date:YYMMDDhhmm done date:YYMMDDhhmm stat:DDDDDDD err:E text:...
+type DeliveryReceipt struct {
+	// MessageID is the id of the original submitted message.
+	MessageID string
+
+	// Submitted is the number of short messages originally submitted.
+	Submitted int
+
+	// Delivered is the number of short messages delivered.
+	Delivered int
+
+	SubmitDate time.Time
+	DoneDate   time.Time
+
+	// Stat is the final state of the message, i.e. DELIVRD, UNDELIV.
+	Stat string
+
+	// Err is network or SMSC specific error code.
+	Err string
+
+	// Text is the first characters of the original message.
+	Text string
+
+	// MessageState is value of message_state TLV or state derived from Stat.
+	// Zero means unknown.
+	MessageState byte
+
+	// NetworkError is value of network_error_code TLV, if any.
+	NetworkError *NetworkErrorCode
+}
+
+// ParseDeliveryReceipt parses delivery receipt text.
+//
+// Parsing is tolerant to common vendor variations: letter case of field names,
+// `submit_date`/`submitdate` spelling, missing fields, 10, 12 or 14 digit dates
+// and SMPP absolute time format. Dates without UTC offset are parsed in UTC.
+func ParseDeliveryReceipt(text string) (r *DeliveryReceipt, err error) {
+	matches := deliveryReceiptKeys.FindAllStringSubmatchIndex(text, -1)
+	if len(matches) == 0 {
+		return nil, ErrInvalidDeliveryReceipt
+	}
+
+	r = &DeliveryReceipt{}
+	for i, m := range matches {
+		key := strings.ToLower(strings.NewReplacer(" ", "", "_", "").Replace(text[m[2]:m[3]]))
+
+		// text is the last field, takes the rest
+		end := len(text)
+		if key != "text" && i+1 < len(matches) {
+			end = matches[i+1][0]
+		}
+		value := strings.TrimSpace(text[m[1]:end])
+
+		switch key {
+		case "id":
+			r.MessageID = value
+		case "sub":
+			r.Submitted, _ = strconv.Atoi(value)
+		case "dlvrd":
+			r.Delivered, _ = strconv.Atoi(value)
+		case "submitdate":
+			r.SubmitDate = parseDeliveryReceiptDate(value)
+		case "donedate":
+			r.DoneDate = parseDeliveryReceiptDate(value)
+		case "stat":
+			r.Stat = strings.ToUpper(value)
+			if alias, ok := deliveryReceiptStatAliases[r.Stat]; ok {
+				r.Stat = alias
+			}
+			r.MessageState = deliveryReceiptStates[r.Stat]
+		case "err":
+			r.Err = value
+		case "text":
+			r.Text = text[m[1]:]
+			if len(r.Text) > 0 && r.Text[0] == ' ' {
+				r.Text = r.Text[1:]
+			}
+		}
+
+		if key == "text" {
+			break
+		}
+	}
+
+	return
+}
+
+func parseDeliveryReceiptDate(value string) (t time.Time) {
+	if len(value) == smppTimeLen {
+		if parsed, err := ParseSMPPTime(value); err == nil && !parsed.IsRelative() {
+			return parsed.Time()
+		}
+		return
+	}
+
+	for _, layout := range deliveryReceiptDateLayouts {
+		if len(layout) == len(value) {
+			if parsed, err := time.ParseInLocation(layout, value, time.UTC); err == nil {
+				return parsed
+			}
+		}
+	}
+	return
+}
+
+// String formats delivery receipt as short message text.
+func (r *DeliveryReceipt) String() string {
+	formatDate := func(t time.Time) string {
+		if t.IsZero() {
+			return ""
+		}
+		return t.Format("0601021504")
+	}
+
+	return fmt.Sprintf("id:%s sub:%03d dlvrd:%03d submit date:%s done date:%s stat:%s err:%s text:%s",
+		r.MessageID, r.Submitted, r.Delivered,
+		formatDate(r.SubmitDate), formatDate(r.DoneDate),
+		r.Stat, r.Err, r.Text)
+}
+
+// applyTLVs fills in receipt from receipted_message_id, message_state
+// and network_error_code optional parameters, which take precedence over text.
+func (r *DeliveryReceipt) applyTLVs(params map[Tag]Field) {
+	if f, ok := params[TagReceiptedMessageID]; ok && len(f.Data) > 0 {
+		r.MessageID = f.String()
+	}
+
+	if f, ok := params[TagMessageState]; ok {
+		if state, err := f.Uint8(); err == nil {
+			r.MessageState = state
+			if r.Stat == "" {
+				for stat, state := range deliveryReceiptStates {
+					if state == r.MessageState {
+						r.Stat = stat
+						break
+					}
+				}
+			}
+		}
+	}
+
+	if f, ok := params[TagNetworkErrorCode]; ok {
+		if code, err := f.NetworkErrorCode(); err == nil {
+			r.NetworkError = &code
+		}
+	}
+}
+
+// tlvs returns optional parameters carrying receipt information.
+func (r *DeliveryReceipt) tlvs() (fields []Field) {
+	if r.MessageID != "" {
+		fields = append(fields, Field{Tag: TagReceiptedMessageID, Data: append([]byte(r.MessageID), 0)})
+	}
+
+	state := r.MessageState
+	if state == 0 {
+		state = deliveryReceiptStates[r.Stat]
+	}
+	if state != 0 {
+		fields = append(fields, Field{Tag: TagMessageStateOption, Data: []byte{state}})
+	}
+
+	if r.NetworkError != nil {
+		fields = append(fields, Field{Tag: TagNetworkErrorCode, Data: []byte{
+			r.NetworkError.NetworkType, byte(r.NetworkError.ErrorCode >> 8), byte(r.NetworkError.ErrorCode),
+		}})
+	}
+	return
+}
+
+// NewDeliverSMFromReceipt builds DeliverSM carrying the delivery receipt, with esm_class
+// flagged as SMSC delivery receipt and receipted_message_id, message_state and
+// network_error_code TLVs set. Source and destination addresses are left to caller.
+func NewDeliverSMFromReceipt(r *DeliveryReceipt) (c *DeliverSM, err error) {
+	c = NewDeliverSM().(*DeliverSM)
+	c.EsmClass = data.SM_SMSC_DLV_RCPT_TYPE
+
+	if c.Message, err = NewShortMessageWithEncoding(r.String(), data.GSM7BIT); err != nil {
+		// fallback for text which is not representable in GSM 7-bit
+		if c.Message, err = NewShortMessageWithEncoding(r.String(), data.UCS2); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, f := range r.tlvs() {
+		c.RegisterOptionalParam(f)
+	}
+	return
+}
+
+// IsDeliveryReceipt checks if esm_class indicates SMSC delivery receipt
+// or intermediate delivery notification.
+func (c *DeliverSM) IsDeliveryReceipt() bool {
+	messageType := c.EsmClass & 0x3C
+	return messageType == data.SM_SMSC_DLV_RCPT_TYPE || messageType == data.SM_INTMD_DLV_NOTIFY_TYPE
+}
+
+// DeliveryReceipt parses delivery receipt carried within this DeliverSM.
+//
+// Receipt text is taken from short message or message_payload TLV. Returns ErrNotDeliveryReceipt
+// if esm_class does not indicate a delivery receipt.
+func (c *DeliverSM) DeliveryReceipt() (r *DeliveryReceipt, err error) {
+	if !c.IsDeliveryReceipt() {
+		return nil, ErrNotDeliveryReceipt
+	}
+
+	text, err := c.Message.GetMessage()
+	if err != nil {
+		return
+	}
+	if text == "" {
+		if f, ok := c.OptionalParameters[TagMessagePayload]; ok {
+			text = f.String()
+		}
+	}
+
+	if r, err = ParseDeliveryReceipt(text); err != nil {
+		// receipt could be carried entirely within TLVs
+		if _, ok := c.OptionalParameters[TagReceiptedMessageID]; !ok {
+			return
+		}
+		r, err = &DeliveryReceipt{}, nil
+	}
+
+	r.applyTLVs(c.OptionalParameters)
+	return
+}
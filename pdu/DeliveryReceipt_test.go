@@ -0,0 +1,99 @@
+package pdu
+
+import (
+	"testing"
+	"time"
+
+	"github.com/linxGnu/gosmpp/data"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDeliveryReceipt(t *testing.T) {
+	t.Run("Standard", func(t *testing.T) {
+		r, err := ParseDeliveryReceipt("id:0123456789 sub:001 dlvrd:001 submit date:2301011200 done date:2301011201 stat:DELIVRD err:000 text:Hello world: id:1")
+		require.Nil(t, err)
+		require.Equal(t, "0123456789", r.MessageID)
+		require.Equal(t, 1, r.Submitted)
+		require.Equal(t, 1, r.Delivered)
+		require.Equal(t, time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC), r.SubmitDate)
+		require.Equal(t, time.Date(2023, 1, 1, 12, 1, 0, 0, time.UTC), r.DoneDate)
+		require.Equal(t, DeliveryReceiptDelivered, r.Stat)
+		require.EqualValues(t, data.SM_STATE_DELIVERED, r.MessageState)
+		require.Equal(t, "000", r.Err)
+		require.Equal(t, "Hello world: id:1", r.Text)
+	})
+
+	t.Run("VendorVariations", func(t *testing.T) {
+		r, err := ParseDeliveryReceipt("ID:abc-def Sub:1 Dlvrd:0 Submit_Date:230101120030 Done_Date:20230101120100 Stat:undelivered Err:0x0b")
+		require.Nil(t, err)
+		require.Equal(t, "abc-def", r.MessageID)
+		require.Equal(t, 0, r.Delivered)
+		require.Equal(t, time.Date(2023, 1, 1, 12, 0, 30, 0, time.UTC), r.SubmitDate)
+		require.Equal(t, time.Date(2023, 1, 1, 12, 1, 0, 0, time.UTC), r.DoneDate)
+		require.Equal(t, DeliveryReceiptUndeliverable, r.Stat)
+		require.Equal(t, "0x0b", r.Err)
+		require.Empty(t, r.Text)
+	})
+
+	t.Run("SMPPTime", func(t *testing.T) {
+		r, err := ParseDeliveryReceipt("id:1 submit date:230101120030004+ done date:000001000000000R stat:DELIVRD")
+		require.Nil(t, err)
+		require.True(t, time.Date(2023, 1, 1, 11, 0, 30, 0, time.UTC).Equal(r.SubmitDate))
+		require.True(t, r.DoneDate.IsZero())
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		_, err := ParseDeliveryReceipt("hello world")
+		require.ErrorIs(t, err, ErrInvalidDeliveryReceipt)
+	})
+}
+
+func TestDeliverSMDeliveryReceipt(t *testing.T) {
+	receipt := &DeliveryReceipt{
+		MessageID:    "msg-1",
+		Submitted:    1,
+		Delivered:    0,
+		SubmitDate:   time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC),
+		DoneDate:     time.Date(2023, 1, 1, 12, 1, 0, 0, time.UTC),
+		Stat:         DeliveryReceiptUndeliverable,
+		Err:          "012",
+		Text:         "Hello",
+		NetworkError: &NetworkErrorCode{NetworkType: 3, ErrorCode: 0x0102},
+	}
+	require.Equal(t, "id:msg-1 sub:001 dlvrd:000 submit date:2301011200 done date:2301011201 stat:UNDELIV err:012 text:Hello", receipt.String())
+
+	d, err := NewDeliverSMFromReceipt(receipt)
+	require.Nil(t, err)
+	require.True(t, d.IsDeliveryReceipt())
+
+	// marshal and parse again
+	buf := NewBuffer(nil)
+	d.Marshal(buf)
+	p, err := Parse(buf)
+	require.Nil(t, err)
+
+	parsed, err := p.(*DeliverSM).DeliveryReceipt()
+	require.Nil(t, err)
+
+	receipt.MessageState = data.SM_STATE_UNDELIVERABLE
+	require.Equal(t, receipt, parsed)
+
+	// TLV takes precedence
+	d.RegisterOptionalParam(Field{Tag: TagMessageStateOption, Data: []byte{data.SM_STATE_EXPIRED}})
+	parsed, err = d.DeliveryReceipt()
+	require.Nil(t, err)
+	require.EqualValues(t, data.SM_STATE_EXPIRED, parsed.MessageState)
+
+	// receipt within TLVs only
+	d.Message, _ = NewShortMessage("")
+	parsed, err = d.DeliveryReceipt()
+	require.Nil(t, err)
+	require.Equal(t, "msg-1", parsed.MessageID)
+	require.Equal(t, DeliveryReceiptExpired, parsed.Stat)
+
+	// not a receipt
+	d.EsmClass = 0
+	_, err = d.DeliveryReceipt()
+	require.ErrorIs(t, err, ErrNotDeliveryReceipt)
+}
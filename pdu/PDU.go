@@ -33,7 +33,9 @@ type PDU interface {
 	// IsGNack returns true if PDU is GNack.
 	IsGNack() bool
 
-	// AssignSequenceNumber assigns sequence number auto-incrementally.
+	// AssignSequenceNumber assigns sequence number auto-incrementally, from a package-level counter.
+	//
+	// Session re-assigns sequence number of request from its connection's SequenceGenerator when writing.
 	AssignSequenceNumber()
 
 	// ResetSequenceNumber resets sequence number.
@@ -117,6 +119,33 @@ func (c *base) unmarshalOptionalParam(optParam []byte) (err error) {
 	return
 }
 
+// unmarshalRepeatedParams reads optional parameters remaining in body, given buffer length at
+// beginning of body. Parameters accepted by collect are not kept in OptionalParameters,
+// e.g. repeatable broadcast_area_identifier(s), which would otherwise overwrite each other.
+func (c *base) unmarshalRepeatedParams(b *ByteBuffer, bodyStart int, collect func(Field) bool) (err error) {
+	n := int(c.CommandLength) - data.PDU_HEADER_SIZE - (bodyStart - b.Len())
+	if n <= 0 {
+		return
+	}
+
+	optParam, err := b.ReadN(n)
+	if err != nil {
+		return
+	}
+
+	buf := NewBuffer(optParam)
+	for buf.Len() > 0 {
+		var field Field
+		if err = field.Unmarshal(buf); err != nil {
+			return
+		}
+		if !collect(field) {
+			c.OptionalParameters[field.Tag] = field
+		}
+	}
+	return
+}
+
 // Marshal to buffer.
 func (c *base) marshal(b *ByteBuffer, bodyWriter func(*ByteBuffer)) {
 	bodyBuf := NewBuffer(nil)
@@ -188,3 +217,21 @@ func Parse(r io.Reader) (pdu PDU, err error) {
 
 	return
 }
+
+// ParseWithEncodings parses PDU from reader, resolving encoding of short message
+// with given data_coding overrides, e.g. per session.
+func ParseWithEncodings(r io.Reader, encodings data.Encodings) (pdu PDU, err error) {
+	if pdu, err = Parse(r); err == nil && len(encodings) > 0 {
+		switch p := pdu.(type) {
+		case *SubmitSM:
+			p.Message.applyEncodings(encodings)
+		case *SubmitMulti:
+			p.Message.applyEncodings(encodings)
+		case *DeliverSM:
+			p.Message.applyEncodings(encodings)
+		case *ReplaceSM:
+			p.Message.applyEncodings(encodings)
+		}
+	}
+	return
+}
@@ -54,7 +54,9 @@ func (c *Header) Unmarshal(b *ByteBuffer) (err error) {
 
 var sequenceNumber int32
 
-// AssignSequenceNumber assigns sequence number auto-incrementally.
+// AssignSequenceNumber assigns sequence number auto-incrementally, from a package-level counter.
+//
+// Session re-assigns sequence number of request from its connection's SequenceGenerator when writing.
 func (c *Header) AssignSequenceNumber() {
 	c.SetSequenceNumber(nextSequenceNumber(&sequenceNumber))
 }
@@ -3,6 +3,7 @@ package pdu
 import (
 	"testing"
 
+	"github.com/linxGnu/gosmpp/data"
 	"github.com/linxGnu/gosmpp/errors"
 
 	"github.com/stretchr/testify/require"
@@ -57,3 +58,36 @@ func TestParsePDU(t *testing.T) {
 		}))
 	})
 }
+
+func TestParseWithEncodings(t *testing.T) {
+	v := NewDeliverSM().(*DeliverSM)
+	_ = v.Message.SetMessageDataWithEncoding([]byte("caf\xe9"), data.GSM7BIT)
+
+	b := NewBuffer(nil)
+	v.Marshal(b)
+	raw := b.Bytes()
+
+	p, err := ParseWithEncodings(NewBuffer(raw), data.Encodings{data.GSM7BITCoding: data.LATIN1})
+	require.NoError(t, err)
+
+	message := p.(*DeliverSM).Message
+	require.EqualValues(t, data.GSM7BITCoding, message.Encoding().DataCoding())
+	text, err := message.GetMessage()
+	require.NoError(t, err)
+	require.Equal(t, "café", text)
+
+	// without overrides
+	p, err = ParseWithEncodings(NewBuffer(raw), nil)
+	require.NoError(t, err)
+	require.Equal(t, data.GSM7BIT, p.(*DeliverSM).Message.Encoding())
+
+	// globally registered
+	data.RegisterEncoding(data.GSM7BITCoding, data.LATIN1)
+	defer data.RegisterEncoding(data.GSM7BITCoding, nil)
+
+	p, err = Parse(NewBuffer(raw))
+	require.NoError(t, err)
+	text, err = p.(*DeliverSM).Message.GetMessage()
+	require.NoError(t, err)
+	require.Equal(t, "café", text)
+}
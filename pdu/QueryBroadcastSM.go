@@ -0,0 +1,55 @@
+package pdu
+
+import (
+	"github.com/linxGnu/gosmpp/data"
+)
+
+// QueryBroadcastSM PDU is issued by the ESME to query the status of a previously submitted
+// broadcast message, as defined by SMPP 5.0. The matching mechanism is based on the Message Centre
+// assigned message_id and source address.
+type QueryBroadcastSM struct {
+	base
+	MessageID  string
+	SourceAddr Address
+}
+
+// NewQueryBroadcastSM returns QueryBroadcastSM PDU.
+func NewQueryBroadcastSM() PDU {
+	c := &QueryBroadcastSM{
+		base:       newBase(),
+		MessageID:  data.DFLT_MSGID,
+		SourceAddr: NewAddress(),
+	}
+	c.CommandID = data.QUERY_BROADCAST_SM
+	return c
+}
+
+// CanResponse implements PDU interface.
+func (c *QueryBroadcastSM) CanResponse() bool {
+	return true
+}
+
+// GetResponse implements PDU interface.
+func (c *QueryBroadcastSM) GetResponse() PDU {
+	return NewQueryBroadcastSMRespFromReq(c)
+}
+
+// Marshal implements PDU interface.
+func (c *QueryBroadcastSM) Marshal(b *ByteBuffer) {
+	c.base.marshal(b, func(b *ByteBuffer) {
+		b.Grow(len(c.MessageID) + 1)
+
+		_ = b.WriteCString(c.MessageID)
+		c.SourceAddr.Marshal(b)
+	})
+}
+
+// Unmarshal implements PDU interface.
+func (c *QueryBroadcastSM) Unmarshal(b *ByteBuffer) error {
+	return c.base.unmarshal(b, func(b *ByteBuffer) (err error) {
+		if c.MessageID, err = b.ReadCString(); err == nil {
+			err = c.SourceAddr.Unmarshal(b)
+		}
+		return
+	})
+}
@@ -0,0 +1,75 @@
+package pdu
+
+import (
+	"github.com/linxGnu/gosmpp/data"
+)
+
+// QueryBroadcastSMResp PDU.
+//
+// Mandatory message_state TLV must be registered as optional parameter.
+type QueryBroadcastSMResp struct {
+	base
+	MessageID string
+
+	// AreaIdentifiers is list of broadcast_area_identifier TLVs.
+	AreaIdentifiers []BroadcastAreaIdentifier
+
+	// AreaSuccess is list of broadcast_area_success TLVs, i.e. success rate in percent
+	// of each of AreaIdentifiers respectively. 255 indicates that information is unavailable.
+	AreaSuccess []byte
+}
+
+// NewQueryBroadcastSMResp returns QueryBroadcastSMResp.
+func NewQueryBroadcastSMResp() PDU {
+	c := &QueryBroadcastSMResp{
+		base:      newBase(),
+		MessageID: data.DFLT_MSGID,
+	}
+	c.CommandID = data.QUERY_BROADCAST_SM_RESP
+	return c
+}
+
+// NewQueryBroadcastSMRespFromReq returns QueryBroadcastSMResp.
+func NewQueryBroadcastSMRespFromReq(req *QueryBroadcastSM) PDU {
+	c := NewQueryBroadcastSMResp().(*QueryBroadcastSMResp)
+	if req != nil {
+		c.SequenceNumber = req.SequenceNumber
+	}
+	return c
+}
+
+// CanResponse implements PDU interface.
+func (c *QueryBroadcastSMResp) CanResponse() bool {
+	return false
+}
+
+// GetResponse implements PDU interface.
+func (c *QueryBroadcastSMResp) GetResponse() PDU {
+	return nil
+}
+
+// Marshal implements PDU interface.
+func (c *QueryBroadcastSMResp) Marshal(b *ByteBuffer) {
+	c.base.marshal(b, func(b *ByteBuffer) {
+		b.Grow(len(c.MessageID) + 1)
+
+		_ = b.WriteCString(c.MessageID)
+		marshalAreaIdentifiers(b, c.AreaIdentifiers)
+		for _, rate := range c.AreaSuccess {
+			f := Field{Tag: TagBroadcastAreaSuccess, Data: []byte{rate}}
+			f.Marshal(b)
+		}
+	})
+}
+
+// Unmarshal implements PDU interface.
+func (c *QueryBroadcastSMResp) Unmarshal(b *ByteBuffer) error {
+	return c.base.unmarshal(b, func(b *ByteBuffer) (err error) {
+		bodyStart := b.Len()
+
+		if c.MessageID, err = b.ReadCString(); err == nil {
+			c.AreaIdentifiers, err = c.unmarshalAreaIdentifiers(b, bodyStart, &c.AreaSuccess)
+		}
+		return
+	})
+}
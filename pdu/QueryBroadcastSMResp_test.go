@@ -0,0 +1,36 @@
+package pdu
+
+import (
+	"testing"
+
+	"github.com/linxGnu/gosmpp/data"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestQueryBroadcastSMResp(t *testing.T) {
+	req := NewQueryBroadcastSM().(*QueryBroadcastSM)
+	req.SequenceNumber = 13
+
+	v := NewQueryBroadcastSMRespFromReq(req).(*QueryBroadcastSMResp)
+	require.False(t, v.CanResponse())
+	require.Nil(t, v.GetResponse())
+
+	v.MessageID = "id"
+	v.AreaIdentifiers = []BroadcastAreaIdentifier{{Format: 0x00, Details: []byte("ab")}}
+	v.AreaSuccess = []byte{100}
+
+	validate(t,
+		v,
+		"0000001f80000112000000000000000d696400060600030061620608000164",
+		data.QUERY_BROADCAST_SM_RESP,
+	)
+
+	require.NoError(t, v.SetTLVUint8(TagMessageState, data.SM_STATE_DELIVERED))
+	v.AreaIdentifiers = append(v.AreaIdentifiers, BroadcastAreaIdentifier{Format: 0x00, Details: []byte("cd")})
+	v.AreaSuccess = append(v.AreaSuccess, 255)
+
+	b := NewBuffer(nil)
+	v.Marshal(b)
+	expectAfterParse(t, b, v, data.QUERY_BROADCAST_SM_RESP)
+}
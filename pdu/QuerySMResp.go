@@ -8,7 +8,7 @@ import (
 type QuerySMResp struct {
 	base
 	MessageID    string
-	FinalDate    string
+	FinalDate    SMPPTime
 	MessageState byte
 	ErrorCode    byte
 }
@@ -17,7 +17,6 @@ type QuerySMResp struct {
 func NewQuerySMResp() PDU {
 	c := &QuerySMResp{
 		base:         newBase(),
-		FinalDate:    data.DFLT_DATE,
 		MessageState: data.DFLT_MSG_STATE,
 		ErrorCode:    data.DFLT_ERR,
 	}
@@ -47,10 +46,10 @@ func (c *QuerySMResp) GetResponse() PDU {
 // Marshal implements PDU interface.
 func (c *QuerySMResp) Marshal(b *ByteBuffer) {
 	c.base.marshal(b, func(b *ByteBuffer) {
-		b.Grow(len(c.MessageID) + len(c.FinalDate) + 4)
+		b.Grow(len(c.MessageID) + smppTimeLen + 4)
 
 		_ = b.WriteCString(c.MessageID)
-		_ = b.WriteCString(c.FinalDate)
+		c.FinalDate.Marshal(b)
 		_ = b.WriteByte(c.MessageState)
 		_ = b.WriteByte(c.ErrorCode)
 	})
@@ -60,7 +59,7 @@ func (c *QuerySMResp) Marshal(b *ByteBuffer) {
 func (c *QuerySMResp) Unmarshal(b *ByteBuffer) error {
 	return c.base.unmarshal(b, func(b *ByteBuffer) (err error) {
 		if c.MessageID, err = b.ReadCString(); err == nil {
-			if c.FinalDate, err = b.ReadCString(); err == nil {
+			if err = c.FinalDate.Unmarshal(b); err == nil {
 				if c.MessageState, err = b.ReadByte(); err == nil {
 					c.ErrorCode, err = b.ReadByte()
 				}
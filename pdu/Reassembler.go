@@ -0,0 +1,383 @@
+package pdu
+
+import (
+	"encoding/binary"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/linxGnu/gosmpp/data"
+)
+
+const (
+	// DefaultReassemblyTimeout is the default waiting duration for all parts of a concatenated message.
+	DefaultReassemblyTimeout = 5 * time.Minute
+
+	// DefaultReassemblyMaxPending is the default maximum number of incomplete messages buffered.
+	DefaultReassemblyMaxPending = 1024
+)
+
+var (
+	// ErrNotReassemblable indicates that PDU could not carry a concatenated message part.
+	ErrNotReassemblable = fmt.Errorf("PDU is neither DeliverSM nor DataSM")
+
+	// ErrInvalidConcatPart indicates that concatenation info of a part is malformed,
+	// i.e. part number is zero or greater than total parts.
+	ErrInvalidConcatPart = fmt.Errorf("invalid concatenated message part")
+)
+
+// ReassembledMessage is a complete message, reassembled from its parts.
+type ReassembledMessage struct {
+	SourceAddr Address
+	DestAddr   Address
+
+	// Parts are PDUs carrying the message, in order of part number.
+	Parts []PDU
+
+	// Encoding of the message, taken from the first part.
+	Encoding data.Encoding
+
+	// Data is the concatenated user data of all parts, without UDH.
+	Data []byte
+
+	// Message is the decoded text of Data.
+	Message string
+}
+
+// ReassemblerSettings for Reassembler.
+type ReassemblerSettings struct {
+	// Timeout is the maximum waiting duration for all parts of a message,
+	// counted from the first received part.
+	//
+	// Zero duration means DefaultReassemblyTimeout.
+	Timeout time.Duration
+
+	// MaxPending is the maximum number of incomplete messages buffered.
+	// The oldest incomplete message is discarded when the limit is exceeded.
+	//
+	// Zero means DefaultReassemblyMaxPending.
+	MaxPending int
+
+	// OnMessage handles complete message. Mandatory.
+	//
+	// Messages which are not concatenated are passed through as single-part ones.
+	OnMessage func(*ReassembledMessage)
+
+	// OnDiscarded handles parts of incomplete message, discarded due to timeout or MaxPending.
+	//
+	// Handle is optional.
+	OnDiscarded func(parts []PDU)
+}
+
+// Reassembler collects parts of concatenated messages from DeliverSM and DataSM PDUs.
+//
+// Parts are correlated by source address, destination address and reference number, which is
+// taken from 8-bit or 16-bit concatenation UDH IE or sar_msg_ref_num, sar_total_segments and
+// sar_segment_seqnum TLVs. References of different kinds, e.g. 8-bit 0x12 and 16-bit 0x0012, never match.
+//
+// Reassembler is safe for concurrent use.
+type Reassembler struct {
+	settings ReassemblerSettings
+
+	mu      sync.Mutex
+	pending map[reassemblyKey]*reassembly
+	closed  bool
+}
+
+type reassemblyKey struct {
+	source, dest string
+	sar          bool
+	ieID         byte
+	ref          uint16
+	total        byte
+}
+
+type reassembly struct {
+	created time.Time
+	parts   []*concatPart
+	count   int
+	timer   *time.Timer
+}
+
+type concatPart struct {
+	p      PDU
+	source Address
+	dest   Address
+	enc    data.Encoding
+	data   []byte
+
+	concatenated bool
+	sar          bool
+	ieID         byte // concatenated message IE, 8-bit or 16-bit reference number
+	ref          uint16
+	total        byte
+	seq          byte
+}
+
+// NewReassembler returns new Reassembler.
+func NewReassembler(settings ReassemblerSettings) *Reassembler {
+	if settings.Timeout <= 0 {
+		settings.Timeout = DefaultReassemblyTimeout
+	}
+	if settings.MaxPending <= 0 {
+		settings.MaxPending = DefaultReassemblyMaxPending
+	}
+
+	return &Reassembler{
+		settings: settings,
+		pending:  make(map[reassemblyKey]*reassembly),
+	}
+}
+
+// Add a received PDU. OnMessage is called if the message becomes complete.
+//
+// Returns error if PDU could not be parsed as message part. Error in decoding
+// message text is also returned, but OnMessage is still called with raw Data.
+func (r *Reassembler) Add(p PDU) (err error) {
+	part, err := newConcatPart(p)
+	if err != nil {
+		return
+	}
+
+	if !part.concatenated || part.total == 1 {
+		return r.emit([]*concatPart{part})
+	}
+
+	key := reassemblyKey{
+		source: part.source.String(),
+		dest:   part.dest.String(),
+		sar:    part.sar,
+		ieID:   part.ieID,
+		ref:    part.ref,
+		total:  part.total,
+	}
+
+	r.mu.Lock()
+	if r.closed {
+		r.mu.Unlock()
+		return
+	}
+
+	m, ok := r.pending[key]
+	if !ok {
+		evicted := r.evict()
+
+		m = &reassembly{
+			created: time.Now(),
+			parts:   make([]*concatPart, part.total),
+		}
+		m.timer = time.AfterFunc(r.settings.Timeout, func() {
+			r.expire(key, m)
+		})
+		r.pending[key] = m
+
+		defer r.discard(evicted)
+	}
+
+	// duplicated part replaces the previous one
+	if m.parts[part.seq-1] == nil {
+		m.count++
+	}
+	m.parts[part.seq-1] = part
+
+	if m.count < len(m.parts) {
+		r.mu.Unlock()
+		return
+	}
+
+	m.timer.Stop()
+	delete(r.pending, key)
+	r.mu.Unlock()
+
+	return r.emit(m.parts)
+}
+
+// Pending returns the number of incomplete messages.
+func (r *Reassembler) Pending() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.pending)
+}
+
+// Close discards all incomplete messages. Parts added afterward are dropped.
+func (r *Reassembler) Close() {
+	r.mu.Lock()
+	r.closed = true
+	pending := r.pending
+	r.pending = make(map[reassemblyKey]*reassembly)
+	r.mu.Unlock()
+
+	for _, m := range pending {
+		m.timer.Stop()
+		r.discard(m)
+	}
+}
+
+// evict removes the oldest incomplete message if the buffer is full. Must be called under lock.
+func (r *Reassembler) evict() (oldest *reassembly) {
+	if len(r.pending) < r.settings.MaxPending {
+		return
+	}
+
+	var oldestKey reassemblyKey
+	for key, m := range r.pending {
+		if oldest == nil || m.created.Before(oldest.created) {
+			oldestKey, oldest = key, m
+		}
+	}
+
+	oldest.timer.Stop()
+	delete(r.pending, oldestKey)
+	return
+}
+
+func (r *Reassembler) expire(key reassemblyKey, m *reassembly) {
+	r.mu.Lock()
+	if r.pending[key] != m {
+		r.mu.Unlock()
+		return
+	}
+	delete(r.pending, key)
+	r.mu.Unlock()
+
+	r.discard(m)
+}
+
+func (r *Reassembler) discard(m *reassembly) {
+	if m == nil || r.settings.OnDiscarded == nil {
+		return
+	}
+
+	parts := make([]PDU, 0, m.count)
+	for _, part := range m.parts {
+		if part != nil {
+			parts = append(parts, part.p)
+		}
+	}
+	r.settings.OnDiscarded(parts)
+}
+
+func (r *Reassembler) emit(parts []*concatPart) (err error) {
+	first := parts[0]
+
+	msg := &ReassembledMessage{
+		SourceAddr: first.source,
+		DestAddr:   first.dest,
+		Parts:      make([]PDU, 0, len(parts)),
+		Encoding:   first.enc,
+	}
+	for _, part := range parts {
+		msg.Parts = append(msg.Parts, part.p)
+		msg.Data = append(msg.Data, part.data...)
+	}
+
+	if len(msg.Data) > 0 {
+		enc := msg.Encoding
+		if enc == nil {
+			enc = data.GSM7BIT
+		}
+		msg.Message, err = enc.Decode(msg.Data)
+	}
+
+	if r.settings.OnMessage != nil {
+		r.settings.OnMessage(msg)
+	}
+	return
+}
+
+func newConcatPart(p PDU) (part *concatPart, err error) {
+	var (
+		esmClass byte
+		params   map[Tag]Field
+	)
+
+	switch pd := p.(type) {
+	case *DeliverSM:
+		part = &concatPart{
+			p:      p,
+			source: pd.SourceAddr,
+			dest:   pd.DestAddr,
+			enc:    pd.Message.Encoding(),
+		}
+		esmClass, params = pd.EsmClass, pd.OptionalParameters
+
+		if part.data, _ = pd.Message.GetMessageData(); len(part.data) > 0 {
+			part.setConcatRef(pd.Message.UDH())
+		} else if part.data, err = payloadUserData(params, esmClass, part); err != nil {
+			return
+		}
+
+	case *DataSM:
+		part = &concatPart{
+			p:      p,
+			source: pd.SourceAddr,
+			dest:   pd.DestAddr,
+			enc:    data.FromDataCoding(pd.DataCoding),
+		}
+		esmClass, params = pd.EsmClass, pd.OptionalParameters
+
+		if part.data, err = payloadUserData(params, esmClass, part); err != nil {
+			return
+		}
+
+	default:
+		return nil, ErrNotReassemblable
+	}
+
+	// fallback to SAR TLVs
+	if !part.concatenated {
+		ref, okRef := params[TagSarMsgRefNum]
+		total, okTotal := params[TagSarTotalSegments]
+		seq, okSeq := params[TagSarSegmentSeqnum]
+
+		if okRef && okTotal && okSeq {
+			if len(ref.Data) != 2 || len(total.Data) != 1 || len(seq.Data) != 1 {
+				return nil, ErrInvalidConcatPart
+			}
+
+			part.concatenated, part.sar = true, true
+			part.ref = binary.BigEndian.Uint16(ref.Data)
+			part.total, part.seq = total.Data[0], seq.Data[0]
+		}
+	}
+
+	if part.concatenated && (part.total == 0 || part.seq == 0 || part.seq > part.total) {
+		return nil, ErrInvalidConcatPart
+	}
+
+	return
+}
+
+// payloadUserData returns user data carried by message_payload TLV, stripping UDH if any.
+func payloadUserData(params map[Tag]Field, esmClass byte, part *concatPart) (d []byte, err error) {
+	f, ok := params[TagMessagePayload]
+	if !ok || len(f.Data) == 0 {
+		return
+	}
+
+	d = f.Data
+	if esmClass&data.SM_UDH_GSM > 0 {
+		var udh UDH
+		var n int
+		if n, err = udh.UnmarshalBinary(d); err != nil {
+			return
+		}
+		d = d[n:]
+		part.setConcatRef(udh)
+
+		if locking, single, found := udh.GetNationalLanguages(); found && part.enc == data.GSM7BIT {
+			if enc, e := data.GSM7National(locking, single); e == nil {
+				part.enc = enc
+			}
+		}
+	}
+	return
+}
+
+// setConcatRef sets reference number, total and sequence number of part from concatenated message IE, if any.
+func (part *concatPart) setConcatRef(udh UDH) {
+	if ie, ok := udh.concatIE(); ok {
+		part.ieID = ie.ID
+		part.ref, part.total, part.seq, part.concatenated = udh.GetConcatRef()
+	}
+}
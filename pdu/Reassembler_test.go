@@ -0,0 +1,171 @@
+package pdu
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/linxGnu/gosmpp/data"
+
+	"github.com/stretchr/testify/require"
+)
+
+func reparse(t *testing.T, p PDU) PDU {
+	b := NewBuffer(nil)
+	p.Marshal(b)
+	parsed, err := Parse(b)
+	require.Nil(t, err)
+	return parsed
+}
+
+func newDeliverSMPart(t *testing.T, source string, udh UDH, text string) PDU {
+	p := NewDeliverSM().(*DeliverSM)
+	_ = p.SourceAddr.SetAddress(source)
+	_ = p.DestAddr.SetAddress("8888")
+	require.Nil(t, p.Message.SetMessageWithEncoding(text, data.UCS2))
+	if udh != nil {
+		p.Message.SetUDH(udh)
+		p.EsmClass |= data.SM_UDH_GSM
+	}
+	return reparse(t, p)
+}
+
+func newDataSMPart(t *testing.T, ref uint16, total, seq byte, text string) PDU {
+	p := NewDataSM().(*DataSM)
+	_ = p.SourceAddr.SetAddress("1234")
+	_ = p.DestAddr.SetAddress("8888")
+	p.RegisterOptionalParam(Field{Tag: TagMessagePayload, Data: []byte(text)})
+	p.RegisterOptionalParam(Field{Tag: TagSarMsgRefNum, Data: []byte{byte(ref >> 8), byte(ref)}})
+	p.RegisterOptionalParam(Field{Tag: TagSarTotalSegments, Data: []byte{total}})
+	p.RegisterOptionalParam(Field{Tag: TagSarSegmentSeqnum, Data: []byte{seq}})
+	return reparse(t, p)
+}
+
+func TestReassembler(t *testing.T) {
+	t.Run("UDH", func(t *testing.T) {
+		var messages []*ReassembledMessage
+		r := NewReassembler(ReassemblerSettings{
+			OnMessage: func(m *ReassembledMessage) {
+				messages = append(messages, m)
+			},
+		})
+		defer r.Close()
+
+		// 8-bit reference, out of order with duplicate
+		require.Nil(t, r.Add(newDeliverSMPart(t, "1234", UDH{NewIEConcatMessage(3, 2, 7)}, "world ")))
+		require.Nil(t, r.Add(newDeliverSMPart(t, "1234", UDH{NewIEConcatMessage(3, 3, 7)}, "!")))
+		require.Nil(t, r.Add(newDeliverSMPart(t, "1234", UDH{NewIEConcatMessage(3, 2, 7)}, "world ")))
+
+		// same reference from another source
+		require.Nil(t, r.Add(newDeliverSMPart(t, "5678", UDH{NewIEConcatMessage(3, 1, 7)}, "Bye ")))
+		require.Equal(t, 2, r.Pending())
+		require.Empty(t, messages)
+
+		require.Nil(t, r.Add(newDeliverSMPart(t, "1234", UDH{NewIEConcatMessage(3, 1, 7)}, "Hello ")))
+		require.Equal(t, 1, r.Pending())
+		require.Len(t, messages, 1)
+		require.Equal(t, "Hello world !", messages[0].Message)
+		require.Equal(t, "1234", messages[0].SourceAddr.Address())
+		require.Equal(t, data.UCS2, messages[0].Encoding)
+		require.Len(t, messages[0].Parts, 3)
+
+		// 16-bit reference
+		ie16 := func(partNum byte) UDH {
+			return UDH{{ID: data.UDH_CONCAT_MSG_16_BIT_REF, Data: []byte{0x01, 0x02, 2, partNum}}}
+		}
+		require.Nil(t, r.Add(newDeliverSMPart(t, "1234", ie16(2), "part")))
+		require.Nil(t, r.Add(newDeliverSMPart(t, "1234", ie16(1), "first ")))
+		require.Len(t, messages, 2)
+		require.Equal(t, "first part", messages[1].Message)
+
+		// not concatenated
+		require.Nil(t, r.Add(newDeliverSMPart(t, "1234", nil, "single")))
+		require.Len(t, messages, 3)
+		require.Equal(t, "single", messages[2].Message)
+		require.Equal(t, 1, r.Pending())
+	})
+
+	t.Run("ReferenceWidth", func(t *testing.T) {
+		var messages []*ReassembledMessage
+		r := NewReassembler(ReassemblerSettings{
+			OnMessage: func(m *ReassembledMessage) {
+				messages = append(messages, m)
+			},
+		})
+		defer r.Close()
+
+		// 8-bit reference 0x12 and 16-bit reference 0x0012 belong to different messages
+		ie16 := func(partNum byte) UDH {
+			return UDH{{ID: data.UDH_CONCAT_MSG_16_BIT_REF, Data: []byte{0x00, 0x12, 2, partNum}}}
+		}
+		require.Nil(t, r.Add(newDeliverSMPart(t, "1234", UDH{NewIEConcatMessage(2, 1, 0x12)}, "8-bit ")))
+		require.Nil(t, r.Add(newDeliverSMPart(t, "1234", ie16(2), "reference")))
+		require.Equal(t, 2, r.Pending())
+		require.Empty(t, messages)
+
+		require.Nil(t, r.Add(newDeliverSMPart(t, "1234", ie16(1), "16-bit ")))
+		require.Nil(t, r.Add(newDeliverSMPart(t, "1234", UDH{NewIEConcatMessage(2, 2, 0x12)}, "reference")))
+		require.Zero(t, r.Pending())
+		require.Len(t, messages, 2)
+		require.Equal(t, "16-bit reference", messages[0].Message)
+		require.Equal(t, "8-bit reference", messages[1].Message)
+	})
+
+	t.Run("SAR", func(t *testing.T) {
+		var messages []*ReassembledMessage
+		r := NewReassembler(ReassemblerSettings{
+			OnMessage: func(m *ReassembledMessage) {
+				messages = append(messages, m)
+			},
+		})
+		defer r.Close()
+
+		require.Nil(t, r.Add(newDataSMPart(t, 0x1234, 2, 2, "payload")))
+		require.Nil(t, r.Add(newDataSMPart(t, 0x1234, 2, 1, "message ")))
+		require.Len(t, messages, 1)
+		require.Equal(t, "message payload", messages[0].Message)
+
+		require.ErrorIs(t, r.Add(newDataSMPart(t, 0x1234, 2, 3, "invalid")), ErrInvalidConcatPart)
+		require.ErrorIs(t, r.Add(NewSubmitSM()), ErrNotReassemblable)
+	})
+
+	t.Run("Discarding", func(t *testing.T) {
+		var (
+			messages  int
+			discarded = make(chan []PDU, 10)
+		)
+		r := NewReassembler(ReassemblerSettings{
+			Timeout:    100 * time.Millisecond,
+			MaxPending: 2,
+			OnMessage: func(m *ReassembledMessage) {
+				messages++
+			},
+			OnDiscarded: func(parts []PDU) {
+				discarded <- parts
+			},
+		})
+		defer r.Close()
+
+		for ref := byte(1); ref <= 3; ref++ {
+			require.Nil(t, r.Add(newDeliverSMPart(t, "1234", UDH{NewIEConcatMessage(2, 1, ref)}, strings.Repeat("x", int(ref)))))
+			time.Sleep(time.Millisecond)
+		}
+
+		// the oldest one is evicted
+		parts := <-discarded
+		require.Len(t, parts, 1)
+		require.Equal(t, 2, r.Pending())
+
+		// the rest are expired
+		for i := 0; i < 2; i++ {
+			select {
+			case parts = <-discarded:
+				require.Len(t, parts, 1)
+			case <-time.After(time.Second):
+				t.Fatal("incomplete message not expired")
+			}
+		}
+		require.Equal(t, 0, r.Pending())
+		require.Zero(t, messages)
+	})
+}
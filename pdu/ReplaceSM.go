@@ -12,8 +12,8 @@ type ReplaceSM struct {
 	base
 	MessageID            string
 	SourceAddr           Address
-	ScheduleDeliveryTime string
-	ValidityPeriod       string
+	ScheduleDeliveryTime SMPPTime
+	ValidityPeriod       SMPPTime
 	RegisteredDelivery   byte
 	Message              ShortMessage
 }
@@ -23,12 +23,10 @@ func NewReplaceSM() PDU {
 	message, _ := NewShortMessage("")
 	message.withoutDataCoding = true
 	c := &ReplaceSM{
-		base:                 newBase(),
-		SourceAddr:           NewAddress(),
-		ScheduleDeliveryTime: data.DFLT_SCHEDULE,
-		ValidityPeriod:       data.DFLT_VALIDITY,
-		RegisteredDelivery:   data.DFLT_REG_DELIVERY,
-		Message:              message,
+		base:               newBase(),
+		SourceAddr:         NewAddress(),
+		RegisteredDelivery: data.DFLT_REG_DELIVERY,
+		Message:            message,
 	}
 	c.CommandID = data.REPLACE_SM
 	return c
@@ -47,12 +45,12 @@ func (c *ReplaceSM) GetResponse() PDU {
 // Marshal implements PDU interface.
 func (c *ReplaceSM) Marshal(b *ByteBuffer) {
 	c.base.marshal(b, func(b *ByteBuffer) {
-		b.Grow(len(c.MessageID) + len(c.ScheduleDeliveryTime) + len(c.ValidityPeriod) + 4)
+		b.Grow(len(c.MessageID) + 2*smppTimeLen + 4)
 
 		_ = b.WriteCString(c.MessageID)
 		c.SourceAddr.Marshal(b)
-		_ = b.WriteCString(c.ScheduleDeliveryTime)
-		_ = b.WriteCString(c.ValidityPeriod)
+		c.ScheduleDeliveryTime.Marshal(b)
+		c.ValidityPeriod.Marshal(b)
 		_ = b.WriteByte(c.RegisteredDelivery)
 		c.Message.Marshal(b)
 	})
@@ -63,8 +61,8 @@ func (c *ReplaceSM) Unmarshal(b *ByteBuffer) error {
 	return c.base.unmarshal(b, func(b *ByteBuffer) (err error) {
 		if c.MessageID, err = b.ReadCString(); err == nil {
 			if err = c.SourceAddr.Unmarshal(b); err == nil {
-				if c.ScheduleDeliveryTime, err = b.ReadCString(); err == nil {
-					if c.ValidityPeriod, err = b.ReadCString(); err == nil {
+				if err = c.ScheduleDeliveryTime.Unmarshal(b); err == nil {
+					if err = c.ValidityPeriod.Unmarshal(b); err == nil {
 						if c.RegisteredDelivery, err = b.ReadByte(); err == nil {
 							err = c.Message.Unmarshal(b, false)
 						}
@@ -0,0 +1,255 @@
+package pdu
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/linxGnu/gosmpp/errors"
+)
+
+const (
+	smppTimeLen = 16
+
+	// relative time has no calendar, months and years are approximated
+	relativeDay   = 24 * time.Hour
+	relativeMonth = 30 * relativeDay
+	relativeYear  = 365 * relativeDay
+
+	maxRelativeDuration = 99*relativeYear + 11*relativeMonth + 29*relativeDay + 23*time.Hour + 59*time.Minute + 59*time.Second
+)
+
+// SMPPTime represents time value in SMPP format (SMPP 3.4 section 7.1.1),
+// used by schedule_delivery_time, validity_period and final_date fields.
+//
+// Absolute format is `YYMMDDhhmmsstnnp` where `t` is tenths of second, `nn` is
+// the difference between local time and UTC in quarter hours and `p` is `+` or `-`.
+//
+// Relative format is `YYMMDDhhmmss000R`, indicating time interval from current SMSC time.
+// Relative months and years are approximated as 30 and 365 days.
+//
+// Zero value is the NULL (empty) time, which means SMSC default.
+//
+// Malformed value received from peer does not fail parsing of its PDU. It is kept as is
+// and reported by Err, see Raw.
+type SMPPTime struct {
+	absolute time.Time
+	relative time.Duration
+
+	isRelative bool
+
+	// raw and err keep malformed value received from peer
+	raw string
+	err error
+}
+
+// NewAbsoluteTime returns absolute SMPPTime. Year must be in range 2000-2099.
+//
+// Location offset of given time is kept if it is a multiple of 15 minutes,
+// otherwise time is converted to UTC.
+func NewAbsoluteTime(t time.Time) (v SMPPTime, err error) {
+	if t.IsZero() {
+		return
+	}
+
+	if _, offset := t.Zone(); offset%(15*60) != 0 || offset > 48*15*60 || offset < -48*15*60 {
+		t = t.UTC()
+	}
+
+	if year := t.Year(); year < 2000 || year > 2099 {
+		err = errors.ErrWrongDateFormat
+		return
+	}
+
+	v.absolute = t.Truncate(100 * time.Millisecond)
+	return
+}
+
+// NewRelativeTime returns relative SMPPTime. Duration is truncated to seconds
+// and must not be negative or exceed 99 years.
+func NewRelativeTime(d time.Duration) (v SMPPTime, err error) {
+	if d < 0 || d > maxRelativeDuration {
+		err = errors.ErrWrongDateFormat
+		return
+	}
+
+	v.relative = d.Truncate(time.Second)
+	v.isRelative = true
+	return
+}
+
+// ParseSMPPTime parses SMPP absolute or relative time. Empty string is parsed as NULL time.
+//
+// Returns errors.ErrWrongDateFormat if the value is malformed.
+func ParseSMPPTime(s string) (v SMPPTime, err error) {
+	if s == "" {
+		return
+	}
+
+	if len(s) != smppTimeLen {
+		err = errors.ErrWrongDateFormat
+		return
+	}
+
+	// strconv.Atoi accepts signs, so every position but the last one is checked to be a digit
+	for i := 0; i < smppTimeLen-1; i++ {
+		if s[i] < '0' || s[i] > '9' {
+			err = errors.ErrWrongDateFormat
+			return
+		}
+	}
+
+	digits := make([]int, 0, 6)
+	for i := 0; i < 12; i += 2 {
+		n, _ := strconv.Atoi(s[i : i+2])
+		digits = append(digits, n)
+	}
+	yy, mm, dd, hh, mi, ss := digits[0], digits[1], digits[2], digits[3], digits[4], digits[5]
+
+	tenths, _ := strconv.Atoi(s[12:13])
+	quarters, _ := strconv.Atoi(s[13:15])
+
+	switch s[15] {
+	case 'R':
+		if mm > 11 || dd > 30 || hh > 23 || mi > 59 || ss > 59 {
+			err = errors.ErrWrongDateFormat
+			return
+		}
+		return NewRelativeTime(time.Duration(yy)*relativeYear +
+			time.Duration(mm)*relativeMonth +
+			time.Duration(dd)*relativeDay +
+			time.Duration(hh)*time.Hour +
+			time.Duration(mi)*time.Minute +
+			time.Duration(ss)*time.Second)
+
+	case '+', '-':
+		if quarters > 48 {
+			err = errors.ErrWrongDateFormat
+			return
+		}
+
+		offset := quarters * 15 * 60
+		if s[15] == '-' {
+			offset = -offset
+		}
+
+		t := time.Date(2000+yy, time.Month(mm), dd, hh, mi, ss, tenths*int(100*time.Millisecond), time.FixedZone("", offset))
+
+		// reject overflowed values, i.e. month 13 or day 32
+		if t.Year() != 2000+yy || int(t.Month()) != mm || t.Day() != dd || t.Hour() != hh || t.Minute() != mi || t.Second() != ss {
+			err = errors.ErrWrongDateFormat
+			return
+		}
+
+		v.absolute = t
+		return
+
+	default:
+		err = errors.ErrWrongDateFormat
+		return
+	}
+}
+
+// IsZero checks if this is the NULL time.
+func (v SMPPTime) IsZero() bool {
+	return v.err == nil && !v.isRelative && v.absolute.IsZero()
+}
+
+// Err returns errors.ErrWrongDateFormat if received value is malformed, nil otherwise.
+func (v SMPPTime) Err() error {
+	return v.err
+}
+
+// Raw returns received malformed value. Valid value is returned in SMPP format, same as String.
+func (v SMPPTime) Raw() string {
+	if v.err != nil {
+		return v.raw
+	}
+	return v.String()
+}
+
+// IsRelative checks if this is a relative time.
+func (v SMPPTime) IsRelative() bool {
+	return v.isRelative
+}
+
+// Time returns absolute time. For relative time, returns time relative to now.
+func (v SMPPTime) Time() time.Time {
+	return v.TimeFrom(time.Now())
+}
+
+// TimeFrom returns absolute time. For relative time, returns time relative to given one.
+func (v SMPPTime) TimeFrom(ref time.Time) time.Time {
+	if v.isRelative {
+		return ref.Add(v.relative)
+	}
+	return v.absolute
+}
+
+// Duration returns relative duration. For absolute time, returns duration from now.
+func (v SMPPTime) Duration() time.Duration {
+	if v.isRelative {
+		return v.relative
+	}
+	if v.absolute.IsZero() {
+		return 0
+	}
+	return time.Until(v.absolute)
+}
+
+// String returns SMPP formatted time.
+func (v SMPPTime) String() string {
+	switch {
+	case v.isRelative:
+		d := v.relative
+
+		yy := d / relativeYear
+		d -= yy * relativeYear
+		mm := d / relativeMonth
+		d -= mm * relativeMonth
+		dd := d / relativeDay
+		d -= dd * relativeDay
+		hh := d / time.Hour
+		d -= hh * time.Hour
+		mi := d / time.Minute
+		d -= mi * time.Minute
+		ss := d / time.Second
+
+		return fmt.Sprintf("%02d%02d%02d%02d%02d%02d000R", yy, mm, dd, hh, mi, ss)
+
+	case v.absolute.IsZero():
+		return ""
+
+	default:
+		t := v.absolute
+		_, offset := t.Zone()
+
+		sign := '+'
+		if offset < 0 {
+			sign, offset = '-', -offset
+		}
+
+		return fmt.Sprintf("%s%d%02d%c", t.Format("060102150405"), t.Nanosecond()/int(100*time.Millisecond), offset/(15*60), sign)
+	}
+}
+
+// Marshal to buffer. Malformed value is never written, NULL time is written instead.
+func (v SMPPTime) Marshal(b *ByteBuffer) {
+	if v.err != nil {
+		_ = b.WriteCString("")
+		return
+	}
+	_ = b.WriteCString(v.String())
+}
+
+// Unmarshal from buffer. Malformed value is kept and reported by Err, instead of failing.
+func (v *SMPPTime) Unmarshal(b *ByteBuffer) (err error) {
+	var s string
+	if s, err = b.ReadCString(); err == nil {
+		var e error
+		if *v, e = ParseSMPPTime(s); e != nil {
+			*v = SMPPTime{raw: s, err: e}
+		}
+	}
+	return
+}
@@ -0,0 +1,124 @@
+package pdu
+
+import (
+	"testing"
+	"time"
+
+	"github.com/linxGnu/gosmpp/data"
+	"github.com/linxGnu/gosmpp/errors"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSMPPTime(t *testing.T) {
+	t.Run("Absolute", func(t *testing.T) {
+		v, err := ParseSMPPTime("230102150405308-")
+		require.Nil(t, err)
+		require.False(t, v.IsZero())
+		require.False(t, v.IsRelative())
+		require.True(t, time.Date(2023, 1, 2, 17, 4, 5, 300000000, time.UTC).Equal(v.Time()))
+		require.Equal(t, "230102150405308-", v.String())
+
+		v, err = NewAbsoluteTime(time.Date(2024, 2, 29, 23, 59, 1, 950000000, time.FixedZone("", 7*3600)))
+		require.Nil(t, err)
+		require.Equal(t, "240229235901928+", v.String())
+
+		// offset not representable in quarter hours
+		v, err = NewAbsoluteTime(time.Date(2024, 2, 29, 12, 0, 0, 0, time.FixedZone("", 600)))
+		require.Nil(t, err)
+		require.Equal(t, "240229115000000+", v.String())
+
+		_, err = NewAbsoluteTime(time.Date(1999, 12, 31, 0, 0, 0, 0, time.UTC))
+		require.Equal(t, errors.ErrWrongDateFormat, err)
+	})
+
+	t.Run("Relative", func(t *testing.T) {
+		v, err := ParseSMPPTime("000102030405000R")
+		require.Nil(t, err)
+		require.True(t, v.IsRelative())
+		require.Equal(t, 32*24*time.Hour+3*time.Hour+4*time.Minute+5*time.Second, v.Duration())
+		require.Equal(t, "000102030405000R", v.String())
+
+		ref := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+		require.Equal(t, ref.Add(v.Duration()), v.TimeFrom(ref))
+
+		v, err = NewRelativeTime(400*24*time.Hour + 90*time.Minute + 500*time.Millisecond)
+		require.Nil(t, err)
+		require.Equal(t, "010105013000000R", v.String())
+
+		_, err = NewRelativeTime(-time.Second)
+		require.Equal(t, errors.ErrWrongDateFormat, err)
+	})
+
+	t.Run("Null", func(t *testing.T) {
+		v, err := ParseSMPPTime("")
+		require.Nil(t, err)
+		require.True(t, v.IsZero())
+		require.Equal(t, "", v.String())
+		require.Zero(t, v.Duration())
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		for _, s := range []string{
+			"2301021504053",
+			"230102150405308*",
+			"231302150405308+",
+			"230132150405308+",
+			"230102150405349+",
+			"0012020304050000R",
+			"001202030405000R",
+			"23010215040a308+",
+			// signs accepted by strconv.Atoi
+			"23+102150405308+",
+			"2301021504053+8+",
+			"000-01000000000R",
+		} {
+			_, err := ParseSMPPTime(s)
+			require.Equal(t, errors.ErrWrongDateFormat, err, s)
+		}
+	})
+
+	t.Run("PDU", func(t *testing.T) {
+		c := NewSubmitSM().(*SubmitSM)
+		c.ScheduleDeliveryTime, _ = NewAbsoluteTime(time.Date(2023, 1, 2, 15, 4, 5, 0, time.UTC))
+		c.ValidityPeriod, _ = NewRelativeTime(2 * time.Hour)
+
+		b := NewBuffer(nil)
+		c.Marshal(b)
+
+		p, err := Parse(b)
+		require.Nil(t, err)
+
+		parsed := p.(*SubmitSM)
+		require.Equal(t, "230102150405000+", parsed.ScheduleDeliveryTime.String())
+		require.Equal(t, 2*time.Hour, parsed.ValidityPeriod.Duration())
+
+		// malformed time on the wire
+		b = NewBuffer(nil)
+		b.WriteInt(32)
+		b.WriteInt(int32(data.QUERY_SM_RESP))
+		b.WriteInt(0)
+		b.WriteInt(1)
+		_ = b.WriteCString("id")
+		_ = b.WriteCString("2301021504")
+		_ = b.WriteByte(data.SM_STATE_DELIVERED)
+		_ = b.WriteByte(0)
+
+		p, err = Parse(b)
+		require.Nil(t, err)
+
+		finalDate := p.(*QuerySMResp).FinalDate
+		require.Equal(t, errors.ErrWrongDateFormat, finalDate.Err())
+		require.Equal(t, "2301021504", finalDate.Raw())
+		require.False(t, finalDate.IsZero())
+		require.Equal(t, byte(data.SM_STATE_DELIVERED), p.(*QuerySMResp).MessageState)
+
+		// malformed time is not written back
+		b = NewBuffer(nil)
+		p.Marshal(b)
+		p, err = Parse(b)
+		require.Nil(t, err)
+		require.True(t, p.(*QuerySMResp).FinalDate.IsZero())
+		require.Nil(t, p.(*QuerySMResp).FinalDate.Err())
+	})
+}
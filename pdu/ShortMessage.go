@@ -1,6 +1,7 @@
 package pdu
 
 import (
+	"math"
 	"sync/atomic"
 
 	"github.com/linxGnu/gosmpp/data"
@@ -17,6 +18,8 @@ type ShortMessage struct {
 	udHeader          UDH
 	messageData       []byte
 	withoutDataCoding bool // purpose of ReplaceSM usage
+	transliterator    *data.Transliterator
+	replacements      []data.Replacement
 }
 
 // NewShortMessage returns new ShortMessage.
@@ -57,8 +60,35 @@ func NewLongMessageWithEncoding(message string, enc data.Encoding) (s []*ShortMe
 	return sm.split()
 }
 
+// SetTransliterator sets transliterator, which is applied to message by SetMessageWithEncoding and
+// SetLongMessageWithEnc, replacing characters which could not be encoded, e.g. to keep message in GSM 7-bit.
+//
+// Nil transliterator disables transliteration.
+func (c *ShortMessage) SetTransliterator(t *data.Transliterator) {
+	c.transliterator = t
+}
+
+// Replacements returns characters replaced by transliteration of the last set message.
+func (c *ShortMessage) Replacements() []data.Replacement {
+	return c.replacements
+}
+
+func (c *ShortMessage) transliterate(message string, enc data.Encoding) string {
+	c.replacements = nil
+	if c.transliterator != nil {
+		message, c.replacements = c.transliterator.Transliterate(message, enc)
+	}
+	return message
+}
+
 // SetMessageWithEncoding sets message with encoding.
+//
+// Transliterator is applied to message if set.
 func (c *ShortMessage) SetMessageWithEncoding(message string, enc data.Encoding) (err error) {
+	return c.setMessageWithEncoding(c.transliterate(message, enc), enc)
+}
+
+func (c *ShortMessage) setMessageWithEncoding(message string, enc data.Encoding) (err error) {
 	if c.messageData, err = enc.Encode(message); err == nil {
 		if len(c.messageData) > data.SM_MSG_LEN {
 			err = errors.ErrShortMessageLengthTooLarge
@@ -83,8 +113,10 @@ func (c *ShortMessage) SetMessageWithEncoding(message string, enc data.Encoding)
 
 // SetLongMessageWithEnc sets ShortMessage with message longer than  256 bytes
 // callers are expected to call Split() after this
+//
+// Transliterator is applied to message if set.
 func (c *ShortMessage) SetLongMessageWithEnc(message string, enc data.Encoding) (err error) {
-	c.message = message
+	c.message = c.transliterate(message, enc)
 	c.enc = enc
 	return
 }
@@ -129,17 +161,49 @@ func (c *ShortMessage) GetMessage() (st string, err error) {
 // GetMessageWithEncoding returns (decoded) underlying message.
 func (c *ShortMessage) GetMessageWithEncoding(enc data.Encoding) (st string, err error) {
 	if len(c.messageData) > 0 {
-		st, err = enc.Decode(c.messageData)
+		messageData := c.messageData
+		if udhl := c.udHeader.UDHL(); enc == data.GSM7BITPACKED && udhl > 0 { // packed septets follow UDH after fill bits
+			messageData = data.RemoveFillBits(messageData, data.GSM7FillBits(udhl))
+		}
+		st, err = enc.Decode(messageData)
 	}
 	return
 }
 
+// SplitStrategy determines how a long message is carried by multiple PDUs.
+type SplitStrategy byte
+
+const (
+	// SplitUDH8Bit splits message into segments carrying concatenation UDH IE with 8-bit reference number.
+	SplitUDH8Bit SplitStrategy = iota
+
+	// SplitUDH16Bit splits message into segments carrying concatenation UDH IE with 16-bit reference number.
+	SplitUDH16Bit
+
+	// SplitSAR splits message into segments without UDH, concatenation info is carried by
+	// sar_msg_ref_num, sar_total_segments and sar_segment_seqnum TLVs.
+	SplitSAR
+
+	// SplitMessagePayload does not split message, the whole message is carried
+	// by message_payload TLV instead of short_message.
+	SplitMessagePayload
+)
+
 // split one short message and split into multiple short message, with UDH
 // according to 33GP TS 23.040 section 9.2.3.24.1
 //
 // NOTE: split() will return array of length 1 if data length is still within the limit
 // The encoding interface can implement the data.Splitter interface for ad-hoc splitting rule
 func (c *ShortMessage) split() (multiSM []*ShortMessage, err error) {
+	multiSM, _, err = c.splitWithStrategy(SplitUDH8Bit)
+	return
+}
+
+// splitWithStrategy splits short message, returning segments and their common reference number.
+//
+// SplitSAR segments have no concatenation UDH, caller is responsible for setting SAR TLVs.
+// SplitMessagePayload is not handled here.
+func (c *ShortMessage) splitWithStrategy(strategy SplitStrategy) (multiSM []*ShortMessage, ref uint16, err error) {
 	var encoding data.Encoding
 	if c.enc == nil {
 		encoding = data.GSM7BIT
@@ -147,16 +211,7 @@ func (c *ShortMessage) split() (multiSM []*ShortMessage, err error) {
 		encoding = c.enc
 	}
 
-	// check if encoding implements data.Splitter
-	splitter, ok := encoding.(data.Splitter)
-	// check if encoding implements data.Splitter or split is necessary
-	if !ok || !splitter.ShouldSplit(c.message, data.SM_GSM_MSG_LEN) {
-		err = c.SetMessageWithEncoding(c.message, c.enc)
-		multiSM = []*ShortMessage{c}
-		return
-	}
-
-	// Reserve 6 bytes for concat message UDH
+	// Reserve 6 bytes for concat message UDH (7 bytes for 16-bit reference number)
 	//
 	// Good references:
 	// - https://help.goacoustic.com/hc/en-us/articles/360043843154--How-character-encoding-affects-SMS-message-length
@@ -165,33 +220,131 @@ func (c *ShortMessage) split() (multiSM []*ShortMessage, err error) {
 	// Limitation is 160 GSM-7 characters and we also need 6 bytes for UDH
 	// -> 134 octets per segment
 	// -> this leaves 153 GSM-7 characters per segment.
-	segments, err := splitter.EncodeSplit(c.message, data.SM_GSM_MSG_LEN-6)
+	//
+	// National language IEs are reserved by data.EncodeSegments, which is also used by data.EstimateSegments.
+	var reserved uint
+	switch strategy {
+	case SplitUDH8Bit:
+		reserved = data.ConcatUDHLength
+	case SplitUDH16Bit:
+		reserved = data.ConcatUDHLength + 1
+	}
+
+	segments, err := data.EncodeSegments(c.message, encoding, reserved)
 	if err != nil {
-		return nil, err
+		return nil, 0, err
+	}
+
+	// total and sequence number of segments are single octets, both in UDH and SAR
+	if len(segments) > math.MaxUint8 {
+		return nil, 0, errors.ErrTooManySegments
+	}
+
+	// split is not necessary, message is already transliterated
+	if len(segments) == 1 {
+		err = c.setMessageWithEncoding(c.message, c.enc)
+		multiSM = []*ShortMessage{c}
+		return
 	}
 
 	// prealloc result
 	multiSM = make([]*ShortMessage, 0, len(segments))
 
 	// all segments will have the same ref id
-	ref := getRefNum()
+	ref = uint16(getRefNum())
 
 	// construct SM(s)
 	for i, seg := range segments {
 		// create new SM, encode data
-		multiSM = append(multiSM, &ShortMessage{
+		sm := &ShortMessage{
 			enc: c.enc,
 			// message: we don't really care
 			messageData:       seg,
 			withoutDataCoding: c.withoutDataCoding,
-			udHeader:          UDH{NewIEConcatMessage(uint8(len(segments)), uint8(i+1), uint8(ref))},
-		})
+		}
+
+		switch strategy {
+		case SplitUDH8Bit:
+			sm.udHeader = UDH{NewIEConcatMessage(uint8(len(segments)), uint8(i+1), uint8(ref))}
+		case SplitUDH16Bit:
+			sm.udHeader = UDH{NewIEConcatMessage16(uint8(len(segments)), uint8(i+1), ref)}
+		}
+
+		multiSM = append(multiSM, sm)
+	}
+
+	return
+}
+
+// payload returns short message without user data and the encoded message
+// to be carried by message_payload TLV.
+func (c *ShortMessage) payload() (sm *ShortMessage, payload []byte, err error) {
+	enc := c.enc
+	if enc == nil {
+		enc = data.GSM7BIT
+	}
+
+	if payload, err = enc.Encode(c.message); err != nil {
+		return
 	}
 
+	// national language IEs are carried by message_payload as well
+	if udhBin, _ := c.nationalIEs().MarshalBinary(); len(udhBin) > 0 {
+		payload = append(udhBin, payload...)
+	}
+
+	sm = &ShortMessage{
+		SmDefaultMsgID:    c.SmDefaultMsgID,
+		enc:               c.enc,
+		withoutDataCoding: c.withoutDataCoding,
+	}
 	return
 }
 
+// nationalIEs returns national language IEs required by encoding, which are not set in UDH.
+func (c *ShortMessage) nationalIEs() (ies UDH) {
+	shifter, ok := c.enc.(data.NationalLanguageShifter)
+	if !ok {
+		return
+	}
+
+	locking, single := shifter.NationalLanguages()
+	if _, found := c.udHeader.FindInfoElement(data.UDH_NATIONAL_LOCKING_SHIFT); !found && locking != data.NationalLanguageDefault {
+		ies = append(ies, NewIENationalLockingShift(locking))
+	}
+	if _, found := c.udHeader.FindInfoElement(data.UDH_NATIONAL_SINGLE_SHIFT); !found && single != data.NationalLanguageDefault {
+		ies = append(ies, NewIENationalSingleShift(single))
+	}
+	return
+}
+
+// userDataHeader returns UDH to be marshaled, including national language IEs required by encoding.
+func (c *ShortMessage) userDataHeader() UDH {
+	if len(c.messageData) == 0 {
+		return c.udHeader
+	}
+
+	ies := c.nationalIEs()
+	if len(ies) == 0 {
+		return c.udHeader
+	}
+
+	udh := make(UDH, 0, len(c.udHeader)+len(ies))
+	udh = append(udh, c.udHeader...)
+	return append(udh, ies...)
+}
+
+// esmClass returns esm_class with UDHI set if national language IEs are added to short message.
+func (c *ShortMessage) esmClass(esmClass byte) byte {
+	if len(c.messageData) > 0 && len(c.nationalIEs()) > 0 {
+		esmClass |= data.SM_UDH_GSM
+	}
+	return esmClass
+}
+
 // Marshal implements PDU interface.
+//
+// National language IEs are added to UDH if encoding uses national language shift tables.
 func (c *ShortMessage) Marshal(b *ByteBuffer) {
 	var (
 		udhBin []byte
@@ -199,8 +352,8 @@ func (c *ShortMessage) Marshal(b *ByteBuffer) {
 	)
 
 	// Prepend UDH to message data if there are any
-	if c.udHeader != nil && c.udHeader.UDHL() > 0 {
-		udhBin, _ = c.udHeader.MarshalBinary()
+	if udh := c.userDataHeader(); udh.UDHL() > 0 {
+		udhBin, _ = udh.MarshalBinary()
 	}
 
 	b.Grow(int(n) + 3)
@@ -253,8 +406,6 @@ func (c *ShortMessage) Unmarshal(b *ByteBuffer, udhi bool) (err error) {
 	if c.messageData, err = b.ReadN(int(n)); err != nil {
 		return
 	}
-	c.enc = data.FromDataCoding(dataCoding)
-
 	// If short message length is non zero, short message contains User-Data Header
 	// Else UDH should be in TLV field MessagePayload
 	if udhi && n > 0 {
@@ -275,14 +426,52 @@ func (c *ShortMessage) Unmarshal(b *ByteBuffer, udhi bool) (err error) {
 		c.messageData = c.messageData[f:]
 	}
 
+	c.resolveEncoding(dataCoding, nil)
+
 	return
 }
 
+// resolveEncoding resolves message encoding from data_coding, honoring given overrides
+// and globally registered encodings.
+func (c *ShortMessage) resolveEncoding(dataCoding byte, encodings data.Encodings) {
+	if enc, ok := encodings.Lookup(dataCoding); ok {
+		c.enc = enc
+		return
+	}
+
+	dcs := data.DataCodingScheme(dataCoding)
+	c.enc = dcs.Encoding()
+
+	// honor national language shift tables
+	if dcs.Alphabet() == data.AlphabetGSM7 {
+		if locking, single, found := c.udHeader.GetNationalLanguages(); found {
+			if enc, e := data.GSM7National(locking, single); e == nil {
+				c.enc = enc
+			}
+		}
+	}
+}
+
+// applyEncodings re-resolves encoding of unmarshaled message with given overrides.
+// Resolved encodings always keep data_coding value of message.
+func (c *ShortMessage) applyEncodings(encodings data.Encodings) {
+	c.resolveEncoding(byte(c.DataCodingScheme()), encodings)
+}
+
 // Encoding returns message encoding.
 func (c *ShortMessage) Encoding() data.Encoding {
 	return c.enc
 }
 
+// DataCodingScheme returns decoded data_coding of message, e.g. to check message class or
+// message waiting indication.
+func (c *ShortMessage) DataCodingScheme() data.DataCodingScheme {
+	if c.enc == nil {
+		return data.DataCodingScheme(data.GSM7BITCoding)
+	}
+	return data.DataCodingScheme(c.enc.DataCoding())
+}
+
 // returns an atomically incrementing number each time it's called
 func getRefNum() uint32 {
 	return atomic.AddUint32(&ref, 1)
@@ -1,6 +1,7 @@
 package pdu
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/linxGnu/gosmpp/data"
@@ -173,6 +174,104 @@ func TestShortMessage(t *testing.T) {
 		require.Equal(t, "abc", message)
 	})
 
+	t.Run("marshalNationalLanguage", func(t *testing.T) {
+		enc, err := data.GSM7National(data.NationalLanguageTurkish, data.NationalLanguageTurkish)
+		require.NoError(t, err)
+
+		s, err := NewShortMessageWithEncoding("Şık", enc)
+		require.NoError(t, err)
+		require.EqualValues(t, data.SM_UDH_GSM, s.esmClass(0))
+
+		buf := NewBuffer(nil)
+		s.Marshal(buf)
+		require.Equal(t, "00000a062501012401011c076b", toHex(buf.Bytes()))
+
+		// national language IEs are honored
+		parsed := &ShortMessage{}
+		require.NoError(t, parsed.Unmarshal(buf, true))
+		require.Equal(t, enc, parsed.Encoding())
+
+		message, err := parsed.GetMessage()
+		require.NoError(t, err)
+		require.Equal(t, "Şık", message)
+	})
+
+	t.Run("shortMessageSplitNationalLanguage", func(t *testing.T) {
+		enc, err := data.GSM7National(data.NationalLanguageTurkish, data.NationalLanguageDefault)
+		require.NoError(t, err)
+
+		// 137 septets fit without UDH, but not with locking shift IE
+		sm, err := NewLongMessageWithEncoding(strings.Repeat("ı", 137), enc)
+		require.NoError(t, err)
+		require.Equal(t, 2, len(sm))
+		require.Len(t, sm[0].messageData, 131)
+
+		buf := NewBuffer(nil)
+		sm[0].Marshal(buf)
+		require.Equal(t, "00008c08000301020125010107", toHex(buf.Bytes()[:13]))
+		require.Equal(t, 3+140, buf.Len())
+	})
+
+	t.Run("transliteration", func(t *testing.T) {
+		var s ShortMessage
+		s.SetTransliterator(data.DefaultTransliterator)
+		require.NoError(t, s.SetMessageWithEncoding("“Hi” – Zoë", data.GSM7BIT))
+
+		message, err := s.GetMessage()
+		require.NoError(t, err)
+		require.Equal(t, "\"Hi\" - Zoe", message)
+		require.Len(t, s.Replacements(), 4)
+
+		// long message keeps replacements after split
+		require.NoError(t, s.SetLongMessageWithEnc(strings.Repeat("—", 200), data.GSM7BIT))
+		multiSM, err := s.split()
+		require.NoError(t, err)
+		require.Len(t, multiSM, 2)
+		require.Len(t, s.Replacements(), 200)
+
+		require.NoError(t, s.SetLongMessageWithEnc("—", data.GSM7BIT))
+		multiSM, err = s.split()
+		require.NoError(t, err)
+		require.Len(t, multiSM, 1)
+		require.Len(t, s.Replacements(), 1)
+
+		s.SetTransliterator(nil)
+		require.Error(t, s.SetMessageWithEncoding("—", data.GSM7BIT))
+		require.Empty(t, s.Replacements())
+	})
+
+	t.Run("unmarshalDataCodingScheme", func(t *testing.T) {
+		// UCS2 flash message
+		s := &ShortMessage{}
+		require.NoError(t, s.Unmarshal(NewBuffer([]byte{0x18, 0x00, 0x04, 0x00, 0x48, 0x00, 0x69}), false))
+
+		message, err := s.GetMessage()
+		require.NoError(t, err)
+		require.Equal(t, "Hi", message)
+
+		class, ok := s.DataCodingScheme().MessageClass()
+		require.True(t, ok)
+		require.Equal(t, data.MessageClass0, class)
+
+		// data_coding is kept
+		buf := NewBuffer(nil)
+		s.Marshal(buf)
+		require.Equal(t, "1800040048", toHex(buf.Bytes())[:10])
+
+		// voicemail indication, store message in GSM 7-bit
+		s = &ShortMessage{}
+		require.NoError(t, s.Unmarshal(NewBuffer([]byte{0xd8, 0x00, 0x02, 0x48, 0x69}), false))
+
+		message, err = s.GetMessage()
+		require.NoError(t, err)
+		require.Equal(t, "Hi", message)
+
+		active, kind, ok := s.DataCodingScheme().MessageWaiting()
+		require.True(t, ok)
+		require.True(t, active)
+		require.Equal(t, data.IndicationVoicemail, kind)
+	})
+
 	t.Run("shortMessageSplitGSM7_169chars", func(t *testing.T) {
 		// over gsm7 chars limit ( 169/160 ), split
 		sm, err := NewLongMessageWithEncoding("abcdefghijklmnopqrstuvwxyzabcdefghijklmnopqrstuvwxyzabcdefghijklmnopqrstuvwxyzabcdefghijklmnopqrstuvwxyzabcdefghijklmnopqrstuvwxyzabcdefghijklmnopqrstuvwxyz1234123456789", data.GSM7BIT)
@@ -15,8 +15,8 @@ type SubmitMulti struct {
 	EsmClass             byte
 	ProtocolID           byte
 	PriorityFlag         byte
-	ScheduleDeliveryTime string
-	ValidityPeriod       string // not used
+	ScheduleDeliveryTime SMPPTime
+	ValidityPeriod       SMPPTime
 	RegisteredDelivery   byte
 	ReplaceIfPresentFlag byte // not used
 	Message              ShortMessage
@@ -33,8 +33,6 @@ func NewSubmitMulti() PDU {
 		EsmClass:             data.DFLT_ESM_CLASS,
 		ProtocolID:           data.DFLT_PROTOCOLID,
 		PriorityFlag:         data.DFLT_PRIORITY_FLAG,
-		ScheduleDeliveryTime: data.DFLT_SCHEDULE,
-		ValidityPeriod:       data.DFLT_VALIDITY,
 		RegisteredDelivery:   data.DFLT_REG_DELIVERY,
 		ReplaceIfPresentFlag: data.DFTL_REPLACE_IFP,
 		Message:              message,
@@ -56,16 +54,16 @@ func (c *SubmitMulti) GetResponse() PDU {
 // Marshal implements PDU interface.
 func (c *SubmitMulti) Marshal(b *ByteBuffer) {
 	c.base.marshal(b, func(b *ByteBuffer) {
-		b.Grow(len(c.ServiceType) + len(c.ScheduleDeliveryTime) + len(c.ValidityPeriod) + 10)
+		b.Grow(len(c.ServiceType) + 2*smppTimeLen + 10)
 
 		_ = b.WriteCString(c.ServiceType)
 		c.SourceAddr.Marshal(b)
 		c.DestAddrs.Marshal(b)
-		_ = b.WriteByte(c.EsmClass)
+		_ = b.WriteByte(c.Message.esmClass(c.EsmClass))
 		_ = b.WriteByte(c.ProtocolID)
 		_ = b.WriteByte(c.PriorityFlag)
-		_ = b.WriteCString(c.ScheduleDeliveryTime)
-		_ = b.WriteCString(c.ValidityPeriod)
+		c.ScheduleDeliveryTime.Marshal(b)
+		c.ValidityPeriod.Marshal(b)
 		_ = b.WriteByte(c.RegisteredDelivery)
 		_ = b.WriteByte(c.ReplaceIfPresentFlag)
 		c.Message.Marshal(b)
@@ -81,8 +79,8 @@ func (c *SubmitMulti) Unmarshal(b *ByteBuffer) error {
 					if c.EsmClass, err = b.ReadByte(); err == nil {
 						if c.ProtocolID, err = b.ReadByte(); err == nil {
 							if c.PriorityFlag, err = b.ReadByte(); err == nil {
-								if c.ScheduleDeliveryTime, err = b.ReadCString(); err == nil {
-									if c.ValidityPeriod, err = b.ReadCString(); err == nil {
+								if err = c.ScheduleDeliveryTime.Unmarshal(b); err == nil {
+									if err = c.ValidityPeriod.Unmarshal(b); err == nil {
 										if c.RegisteredDelivery, err = b.ReadByte(); err == nil {
 											if c.ReplaceIfPresentFlag, err = b.ReadByte(); err == nil {
 												err = c.Message.Unmarshal(b, (c.EsmClass&data.SM_UDH_GSM) > 0)
@@ -1,7 +1,10 @@
 package pdu
 
 import (
+	"math"
+
 	"github.com/linxGnu/gosmpp/data"
+	"github.com/linxGnu/gosmpp/errors"
 )
 
 // SubmitSM PDU is used by an ESME to submit a short message to the SMSC for onward
@@ -15,8 +18,8 @@ type SubmitSM struct {
 	EsmClass             byte
 	ProtocolID           byte
 	PriorityFlag         byte
-	ScheduleDeliveryTime string // not used
-	ValidityPeriod       string // not used
+	ScheduleDeliveryTime SMPPTime
+	ValidityPeriod       SMPPTime
 	RegisteredDelivery   byte
 	ReplaceIfPresentFlag byte // not used
 	Message              ShortMessage
@@ -33,8 +36,6 @@ func NewSubmitSM() PDU {
 		EsmClass:             data.DFLT_ESM_CLASS,
 		ProtocolID:           data.DFLT_PROTOCOLID,
 		PriorityFlag:         data.DFLT_PRIORITY_FLAG,
-		ScheduleDeliveryTime: data.DFLT_SCHEDULE,
-		ValidityPeriod:       data.DFLT_VALIDITY,
 		RegisteredDelivery:   data.DFLT_REG_DELIVERY,
 		ReplaceIfPresentFlag: data.DFTL_REPLACE_IFP,
 		Message:              message,
@@ -64,50 +65,96 @@ func (c *SubmitSM) GetResponse() PDU {
 // If the message is short enough and doesn't need splitting,
 // Split() returns an array of length 1
 func (c *SubmitSM) Split() (multiSubSM []*SubmitSM, err error) {
-	multiSubSM = []*SubmitSM{}
+	return c.SplitWithStrategy(SplitUDH8Bit)
+}
+
+// SplitWithStrategy split a single long text message into multiple SubmitSM PDU,
+// carrying concatenation info as specified by strategy.
+//
+// With SplitMessagePayload, the whole message is moved into message_payload TLV of a single SubmitSM.
+// If the message is short enough and doesn't need splitting, an array of length 1 is returned.
+func (c *SubmitSM) SplitWithStrategy(strategy SplitStrategy) (multiSubSM []*SubmitSM, err error) {
+	if strategy == SplitMessagePayload {
+		sm, payload, err := c.Message.payload()
+		if err != nil {
+			return nil, err
+		}
+		if len(payload) > math.MaxUint16 {
+			return nil, errors.ErrShortMessageLengthTooLarge
+		}
+
+		esmClass := c.EsmClass
+		if len(sm.nationalIEs()) > 0 {
+			esmClass |= data.SM_UDH_GSM // payload carries national language IEs
+		}
+
+		part := c.splitPart(esmClass, sm)
+		part.RegisterOptionalParam(Field{Tag: TagMessagePayload, Data: payload})
+		return []*SubmitSM{part}, nil
+	}
 
-	multiMsg, err := c.Message.split()
+	multiMsg, ref, err := c.Message.splitWithStrategy(strategy)
 	if err != nil {
 		return
 	}
 
 	esmClass := c.EsmClass // no need to "or" with SM_UDH_GSM when a message has a single part
-	if len(multiMsg) > 1 {
+	if len(multiMsg) > 1 && strategy != SplitSAR {
 		esmClass = c.EsmClass | data.SM_UDH_GSM // must set to indicate UDH
 	}
 
-	for _, msg := range multiMsg {
-		multiSubSM = append(multiSubSM, &SubmitSM{
-			base:                 c.base,
-			ServiceType:          c.ServiceType,
-			SourceAddr:           c.SourceAddr,
-			DestAddr:             c.DestAddr,
-			EsmClass:             esmClass,
-			ProtocolID:           c.ProtocolID,
-			PriorityFlag:         c.PriorityFlag,
-			ScheduleDeliveryTime: c.ScheduleDeliveryTime,
-			ValidityPeriod:       c.ValidityPeriod,
-			RegisteredDelivery:   c.RegisteredDelivery,
-			ReplaceIfPresentFlag: c.ReplaceIfPresentFlag,
-			Message:              *msg,
-		})
+	multiSubSM = make([]*SubmitSM, 0, len(multiMsg))
+	for i, msg := range multiMsg {
+		part := c.splitPart(esmClass, msg)
+
+		if len(multiMsg) > 1 && strategy == SplitSAR {
+			part.RegisterOptionalParam(Field{Tag: TagSarMsgRefNum, Data: []byte{byte(ref >> 8), byte(ref)}})
+			part.RegisterOptionalParam(Field{Tag: TagSarTotalSegments, Data: []byte{byte(len(multiMsg))}})
+			part.RegisterOptionalParam(Field{Tag: TagSarSegmentSeqnum, Data: []byte{byte(i + 1)}})
+		}
+
+		multiSubSM = append(multiSubSM, part)
 	}
 	return
 }
 
+// splitPart returns a copy of SubmitSM carrying given message, with its own optional parameters.
+func (c *SubmitSM) splitPart(esmClass byte, msg *ShortMessage) *SubmitSM {
+	part := &SubmitSM{
+		base:                 c.base,
+		ServiceType:          c.ServiceType,
+		SourceAddr:           c.SourceAddr,
+		DestAddr:             c.DestAddr,
+		EsmClass:             esmClass,
+		ProtocolID:           c.ProtocolID,
+		PriorityFlag:         c.PriorityFlag,
+		ScheduleDeliveryTime: c.ScheduleDeliveryTime,
+		ValidityPeriod:       c.ValidityPeriod,
+		RegisteredDelivery:   c.RegisteredDelivery,
+		ReplaceIfPresentFlag: c.ReplaceIfPresentFlag,
+		Message:              *msg,
+	}
+
+	part.OptionalParameters = make(map[Tag]Field, len(c.OptionalParameters))
+	for tag, field := range c.OptionalParameters {
+		part.OptionalParameters[tag] = field
+	}
+	return part
+}
+
 // Marshal implements PDU interface.
 func (c *SubmitSM) Marshal(b *ByteBuffer) {
 	c.base.marshal(b, func(b *ByteBuffer) {
-		b.Grow(len(c.ServiceType) + len(c.ScheduleDeliveryTime) + len(c.ValidityPeriod) + 10)
+		b.Grow(len(c.ServiceType) + 2*smppTimeLen + 10)
 
 		_ = b.WriteCString(c.ServiceType)
 		c.SourceAddr.Marshal(b)
 		c.DestAddr.Marshal(b)
-		_ = b.WriteByte(c.EsmClass)
+		_ = b.WriteByte(c.Message.esmClass(c.EsmClass))
 		_ = b.WriteByte(c.ProtocolID)
 		_ = b.WriteByte(c.PriorityFlag)
-		_ = b.WriteCString(c.ScheduleDeliveryTime)
-		_ = b.WriteCString(c.ValidityPeriod)
+		c.ScheduleDeliveryTime.Marshal(b)
+		c.ValidityPeriod.Marshal(b)
 		_ = b.WriteByte(c.RegisteredDelivery)
 		_ = b.WriteByte(c.ReplaceIfPresentFlag)
 		c.Message.Marshal(b)
@@ -123,8 +170,8 @@ func (c *SubmitSM) Unmarshal(b *ByteBuffer) error {
 					if c.EsmClass, err = b.ReadByte(); err == nil {
 						if c.ProtocolID, err = b.ReadByte(); err == nil {
 							if c.PriorityFlag, err = b.ReadByte(); err == nil {
-								if c.ScheduleDeliveryTime, err = b.ReadCString(); err == nil {
-									if c.ValidityPeriod, err = b.ReadCString(); err == nil {
+								if err = c.ScheduleDeliveryTime.Unmarshal(b); err == nil {
+									if err = c.ValidityPeriod.Unmarshal(b); err == nil {
 										if c.RegisteredDelivery, err = b.ReadByte(); err == nil {
 											if c.ReplaceIfPresentFlag, err = b.ReadByte(); err == nil {
 												err = c.Message.Unmarshal(b, (c.EsmClass&data.SM_UDH_GSM) > 0)
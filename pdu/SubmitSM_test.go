@@ -1,9 +1,11 @@
 package pdu
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/linxGnu/gosmpp/data"
+	"github.com/linxGnu/gosmpp/errors"
 
 	"github.com/stretchr/testify/require"
 )
@@ -41,3 +43,141 @@ func TestSubmitSM(t *testing.T) {
 		data.SUBMIT_SM,
 	)
 }
+
+func TestSubmitSMSplitWithStrategy(t *testing.T) {
+	text := strings.Repeat("biggest gift của Christmas ", 5) // 135 UCS2 characters
+
+	newSubmitSM := func() *SubmitSM {
+		c := NewSubmitSM().(*SubmitSM)
+		require.Nil(t, c.Message.SetLongMessageWithEnc(text, data.UCS2))
+		c.RegisterOptionalParam(Field{Tag: TagUserMessageReference, Data: []byte{0, 1}})
+		return c
+	}
+
+	decode := func(parts []*SubmitSM) (s string) {
+		for _, part := range parts {
+			msg, err := part.Message.GetMessage()
+			require.Nil(t, err)
+			s += msg
+		}
+		return
+	}
+
+	t.Run("UDH16Bit", func(t *testing.T) {
+		parts, err := newSubmitSM().SplitWithStrategy(SplitUDH16Bit)
+		require.Nil(t, err)
+		require.Len(t, parts, 3)
+		require.Equal(t, text, decode(parts))
+
+		for i, part := range parts {
+			require.NotZero(t, part.EsmClass&data.SM_UDH_GSM)
+			ref, total, seq, found := part.Message.UDH().GetConcatRef()
+			require.True(t, found)
+			require.EqualValues(t, 3, total)
+			require.EqualValues(t, i+1, seq)
+
+			ref0, _, _, _ := parts[0].Message.UDH().GetConcatRef()
+			require.Equal(t, ref0, ref)
+
+			b := NewBuffer(nil)
+			part.Marshal(b)
+			require.LessOrEqual(t, b.Len(), 16+140+64)
+		}
+	})
+
+	t.Run("SAR", func(t *testing.T) {
+		parts, err := newSubmitSM().SplitWithStrategy(SplitSAR)
+		require.Nil(t, err)
+		require.Len(t, parts, 2) // no UDH, 70 characters per segment
+		require.Equal(t, text, decode(parts))
+
+		for i, part := range parts {
+			require.Zero(t, part.EsmClass&data.SM_UDH_GSM)
+			require.Nil(t, part.Message.UDH())
+			require.Equal(t, parts[0].OptionalParameters[TagSarMsgRefNum], part.OptionalParameters[TagSarMsgRefNum])
+			require.Equal(t, []byte{2}, part.OptionalParameters[TagSarTotalSegments].Data)
+			require.Equal(t, []byte{byte(i + 1)}, part.OptionalParameters[TagSarSegmentSeqnum].Data)
+			require.Equal(t, []byte{0, 1}, part.OptionalParameters[TagUserMessageReference].Data)
+		}
+
+		// short message is not split
+		c := NewSubmitSM().(*SubmitSM)
+		require.Nil(t, c.Message.SetLongMessageWithEnc("short", data.UCS2))
+		parts, err = c.SplitWithStrategy(SplitSAR)
+		require.Nil(t, err)
+		require.Len(t, parts, 1)
+		require.Empty(t, parts[0].OptionalParameters)
+	})
+
+	t.Run("GSM7BITPACKED", func(t *testing.T) {
+		// escape characters and lengths leaving 7 spare bits in the last octet
+		for _, packed := range []string{
+			strings.Repeat("hello {world} ", 30),
+			strings.Repeat("a", 161) + strings.Repeat("b", 7),
+			strings.Repeat("a", 175),
+			strings.Repeat("a", 300) + "€",
+		} {
+			for _, strategy := range []SplitStrategy{SplitUDH8Bit, SplitUDH16Bit, SplitSAR, SplitMessagePayload} {
+				c := NewSubmitSM().(*SubmitSM)
+				require.Nil(t, c.Message.SetLongMessageWithEnc(packed, data.GSM7BITPACKED))
+
+				parts, err := c.SplitWithStrategy(strategy)
+				require.Nil(t, err)
+
+				if strategy == SplitMessagePayload {
+					require.Len(t, parts, 1)
+					decoded, err := data.GSM7BITPACKED.Decode(parts[0].OptionalParameters[TagMessagePayload].Data)
+					require.Nil(t, err)
+					require.Equal(t, packed, decoded)
+					continue
+				}
+
+				require.Greater(t, len(parts), 1)
+				for _, part := range parts {
+					b := NewBuffer(nil)
+					part.Message.Marshal(b)
+					require.LessOrEqual(t, b.Len(), 3+data.SM_GSM_MSG_LEN) // data_coding, sm_default_msg_id, sm_length
+				}
+				require.Equal(t, packed, decode(parts), "strategy %d", strategy)
+			}
+		}
+	})
+
+	t.Run("TooManySegments", func(t *testing.T) {
+		// 255 segments of 67 UCS2 characters (66 with 16-bit reference, 70 without UDH) fit in concatenation, the next character does not
+		c := NewSubmitSM().(*SubmitSM)
+		require.Nil(t, c.Message.SetLongMessageWithEnc(strings.Repeat("a", 255*67), data.UCS2))
+		parts, err := c.SplitWithStrategy(SplitUDH8Bit)
+		require.Nil(t, err)
+		require.Len(t, parts, 255)
+
+		for strategy, chars := range map[SplitStrategy]int{SplitUDH8Bit: 67, SplitUDH16Bit: 66, SplitSAR: 70} {
+			c = NewSubmitSM().(*SubmitSM)
+			require.Nil(t, c.Message.SetLongMessageWithEnc(strings.Repeat("a", 255*chars+1), data.UCS2))
+			_, err = c.SplitWithStrategy(strategy)
+			require.Equal(t, errors.ErrTooManySegments, err)
+		}
+	})
+
+	t.Run("MessagePayload", func(t *testing.T) {
+		parts, err := newSubmitSM().SplitWithStrategy(SplitMessagePayload)
+		require.Nil(t, err)
+		require.Len(t, parts, 1)
+
+		b := NewBuffer(nil)
+		parts[0].Marshal(b)
+		p, err := Parse(b)
+		require.Nil(t, err)
+
+		parsed := p.(*SubmitSM)
+		msg, err := parsed.Message.GetMessage()
+		require.Nil(t, err)
+		require.Empty(t, msg)
+		require.Equal(t, data.UCS2, parsed.Message.Encoding())
+
+		f := parsed.OptionalParameters[TagMessagePayload]
+		payload, err := data.UCS2.Decode(f.Data)
+		require.Nil(t, err)
+		require.Equal(t, text, payload)
+	})
+}
@@ -42,6 +42,7 @@ const (
 	TagLanguageIndicator        Tag = 0x020D
 	TagSarTotalSegments         Tag = 0x020E
 	TagSarSegmentSeqnum         Tag = 0x020F
+	TagScInterfaceVersion       Tag = 0x0210
 	TagCallbackNumPresInd       Tag = 0x0302
 	TagCallbackNumAtag          Tag = 0x0303
 	TagNumberOfMessages         Tag = 0x0304
@@ -54,6 +55,8 @@ const (
 	TagDeliveryFailureReason    Tag = 0x0425
 	TagMoreMessagesToSend       Tag = 0x0426
 	TagMessageStateOption       Tag = 0x0427
+	TagMessageState             Tag = TagMessageStateOption
+	TagCongestionState          Tag = 0x0428 // SMPP 5.0
 	TagUssdServiceOp            Tag = 0x0501
 	TagDisplayTime              Tag = 0x1201
 	TagSmsSignal                Tag = 0x1203
@@ -63,6 +66,21 @@ const (
 	TagItsSessionInfo           Tag = 0x1383
 )
 
+// SMPP 5.0 broadcast Tag-Length-Value (TLV) tags.
+const (
+	TagBroadcastChannelIndicator  Tag = 0x0600
+	TagBroadcastContentType       Tag = 0x0601
+	TagBroadcastContentTypeInfo   Tag = 0x0602
+	TagBroadcastMessageClass      Tag = 0x0603
+	TagBroadcastRepNum            Tag = 0x0604
+	TagBroadcastFrequencyInterval Tag = 0x0605
+	TagBroadcastAreaIdentifier    Tag = 0x0606
+	TagBroadcastErrorStatus       Tag = 0x0607
+	TagBroadcastAreaSuccess       Tag = 0x0608
+	TagBroadcastEndTime           Tag = 0x0609
+	TagBroadcastServiceGroup      Tag = 0x060A
+)
+
 // Field is a PDU Tag-Length-Value (TLV) field
 type Field struct {
 	Tag  Tag
@@ -78,8 +96,10 @@ func (t *Field) String() string {
 }
 
 // Marshal to writer.
+//
+// Field without value is omitted, unless its type is TLVEmpty.
 func (t *Field) Marshal(w *ByteBuffer) {
-	if len(t.Data) > 0 {
+	if len(t.Data) > 0 || tlvSpecs[t.Tag].typ == TLVEmpty {
 		w.Grow(4 + len(t.Data))
 
 		w.WriteShort(int16(t.Tag))
@@ -0,0 +1,518 @@
+package pdu
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+var (
+	// ErrTLVNotFound indicates that optional parameter is absent.
+	ErrTLVNotFound = fmt.Errorf("TLV is not found")
+
+	// ErrTLVType indicates that optional parameter value is not of requested type.
+	ErrTLVType = fmt.Errorf("TLV value type mismatch")
+
+	// ErrTLVLength indicates that optional parameter value length violates spec.
+	ErrTLVLength = fmt.Errorf("TLV value length is invalid")
+)
+
+// TLVType is value type of optional parameter, as defined by SMPP 3.4 section 5.3.2.
+type TLVType byte
+
+// TLV value types.
+const (
+	// TLVUnknown is type of vendor specific optional parameter.
+	TLVUnknown TLVType = iota
+
+	// TLVUint8 is 1-octet integer.
+	TLVUint8
+
+	// TLVUint16 is 2-octet integer.
+	TLVUint16
+
+	// TLVUint32 is 4-octet integer.
+	TLVUint32
+
+	// TLVCString is NULL terminated octet string.
+	TLVCString
+
+	// TLVOctetString is octet string.
+	TLVOctetString
+
+	// TLVComposite is octet string with inner structure, e.g. callback_num.
+	TLVComposite
+
+	// TLVEmpty is parameter without value, e.g. alert_on_message_delivery.
+	TLVEmpty
+)
+
+type tlvSpec struct {
+	typ      TLVType
+	min, max int
+}
+
+// tlvSpecs holds spec-mandated value types and lengths, of SMPP 3.4 and SMPP 5.0 broadcast parameters.
+// Lengths of C-Octet Strings include NULL terminator.
+var tlvSpecs = map[Tag]tlvSpec{
+	TagDestAddrSubunit:          {TLVUint8, 1, 1},
+	TagDestNetworkType:          {TLVUint8, 1, 1},
+	TagDestBearerType:           {TLVUint8, 1, 1},
+	TagDestTelematicsID:         {TLVUint16, 2, 2},
+	TagSourceAddrSubunit:        {TLVUint8, 1, 1},
+	TagSourceNetworkType:        {TLVUint8, 1, 1},
+	TagSourceBearerType:         {TLVUint8, 1, 1},
+	TagSourceTelematicsID:       {TLVUint8, 1, 1},
+	TagQosTimeToLive:            {TLVUint32, 4, 4},
+	TagPayloadType:              {TLVUint8, 1, 1},
+	TagAdditionalStatusInfoText: {TLVCString, 1, 256},
+	TagReceiptedMessageID:       {TLVCString, 1, 65},
+	TagMsMsgWaitFacilities:      {TLVUint8, 1, 1},
+	TagPrivacyIndicator:         {TLVUint8, 1, 1},
+	TagSourceSubaddress:         {TLVComposite, 2, 23},
+	TagDestSubaddress:           {TLVComposite, 2, 23},
+	TagUserMessageReference:     {TLVUint16, 2, 2},
+	TagUserResponseCode:         {TLVUint8, 1, 1},
+	TagSourcePort:               {TLVUint16, 2, 2},
+	TagDestinationPort:          {TLVUint16, 2, 2},
+	TagSarMsgRefNum:             {TLVUint16, 2, 2},
+	TagLanguageIndicator:        {TLVUint8, 1, 1},
+	TagSarTotalSegments:         {TLVUint8, 1, 1},
+	TagSarSegmentSeqnum:         {TLVUint8, 1, 1},
+	TagScInterfaceVersion:       {TLVUint8, 1, 1},
+	TagCallbackNumPresInd:       {TLVUint8, 1, 1},
+	TagCallbackNumAtag:          {TLVComposite, 1, 65},
+	TagNumberOfMessages:         {TLVUint8, 1, 1},
+	TagCallbackNum:              {TLVComposite, 4, 19},
+	TagDpfResult:                {TLVUint8, 1, 1},
+	TagSetDpf:                   {TLVUint8, 1, 1},
+	TagMsAvailabilityStatus:     {TLVUint8, 1, 1},
+	TagNetworkErrorCode:         {TLVComposite, 3, 3},
+	TagMessagePayload:           {TLVOctetString, 0, 0xFFFF},
+	TagDeliveryFailureReason:    {TLVUint8, 1, 1},
+	TagMoreMessagesToSend:       {TLVUint8, 1, 1},
+	TagMessageState:             {TLVUint8, 1, 1},
+	TagCongestionState:          {TLVUint8, 1, 1},
+	TagUssdServiceOp:            {TLVUint8, 1, 1},
+	TagDisplayTime:              {TLVUint8, 1, 1},
+	TagSmsSignal:                {TLVUint16, 2, 2},
+	TagMsValidity:               {TLVUint8, 1, 1},
+	TagAlertOnMessageDelivery:   {TLVEmpty, 0, 1}, // SMPP 5.0 allows 1-octet value
+	TagItsReplyType:             {TLVUint8, 1, 1},
+	TagItsSessionInfo:           {TLVComposite, 2, 2},
+
+	TagBroadcastChannelIndicator:  {TLVUint8, 1, 1},
+	TagBroadcastContentType:       {TLVComposite, 3, 3},
+	TagBroadcastContentTypeInfo:   {TLVOctetString, 1, 255},
+	TagBroadcastMessageClass:      {TLVUint8, 1, 1},
+	TagBroadcastRepNum:            {TLVUint16, 2, 2},
+	TagBroadcastFrequencyInterval: {TLVComposite, 3, 3},
+	TagBroadcastAreaIdentifier:    {TLVComposite, 1, 101},
+	TagBroadcastErrorStatus:       {TLVUint32, 4, 4},
+	TagBroadcastAreaSuccess:       {TLVUint8, 1, 1},
+	TagBroadcastEndTime:           {TLVCString, 1, 17},
+	TagBroadcastServiceGroup:      {TLVOctetString, 1, 255},
+}
+
+// TLVSpec returns value type and spec-mandated value length range of optional parameter.
+// Returns false for vendor specific tags.
+func TLVSpec(tag Tag) (typ TLVType, minLen, maxLen int, ok bool) {
+	spec, ok := tlvSpecs[tag]
+	return spec.typ, spec.min, spec.max, ok
+}
+
+// Validate checks value length against spec. Vendor specific fields are always valid.
+func (t *Field) Validate() error {
+	if spec, ok := tlvSpecs[t.Tag]; ok && (len(t.Data) < spec.min || len(t.Data) > spec.max) {
+		return fmt.Errorf("%w: tag 0x%s, %d octets", ErrTLVLength, t.Tag.Hex(), len(t.Data))
+	}
+	return nil
+}
+
+// check validates field against expected value types and, for vendor specific field, length range.
+func (t *Field) check(minLen, maxLen int, types ...TLVType) error {
+	spec, ok := tlvSpecs[t.Tag]
+	if !ok {
+		spec = tlvSpec{typ: TLVUnknown, min: minLen, max: maxLen}
+	} else {
+		matched := false
+		for _, typ := range types {
+			matched = matched || spec.typ == typ
+		}
+		if !matched {
+			return fmt.Errorf("%w: tag 0x%s", ErrTLVType, t.Tag.Hex())
+		}
+	}
+
+	if len(t.Data) < spec.min || len(t.Data) > spec.max {
+		return fmt.Errorf("%w: tag 0x%s, %d octets", ErrTLVLength, t.Tag.Hex(), len(t.Data))
+	}
+	return nil
+}
+
+func newTLV(tag Tag, value []byte, minLen, maxLen int, types ...TLVType) (f Field, err error) {
+	f = Field{Tag: tag, Data: value}
+	err = f.check(minLen, maxLen, types...)
+	return
+}
+
+// NewTLVUint8 returns optional parameter with 1-octet integer value.
+func NewTLVUint8(tag Tag, v uint8) (Field, error) {
+	return newTLV(tag, []byte{v}, 1, 1, TLVUint8)
+}
+
+// NewTLVUint16 returns optional parameter with 2-octet integer value.
+func NewTLVUint16(tag Tag, v uint16) (Field, error) {
+	return newTLV(tag, binary.BigEndian.AppendUint16(nil, v), 2, 2, TLVUint16)
+}
+
+// NewTLVUint32 returns optional parameter with 4-octet integer value.
+func NewTLVUint32(tag Tag, v uint32) (Field, error) {
+	return newTLV(tag, binary.BigEndian.AppendUint32(nil, v), 4, 4, TLVUint32)
+}
+
+// NewTLVCString returns optional parameter with NULL terminated string value.
+func NewTLVCString(tag Tag, v string) (Field, error) {
+	return newTLV(tag, append([]byte(v), 0), 1, 0xFFFF, TLVCString)
+}
+
+// NewTLVOctetString returns optional parameter with octet string value.
+func NewTLVOctetString(tag Tag, v []byte) (Field, error) {
+	return newTLV(tag, v, 0, 0xFFFF, TLVOctetString, TLVComposite)
+}
+
+// NewTLVEmpty returns optional parameter without value, e.g. alert_on_message_delivery.
+func NewTLVEmpty(tag Tag) (Field, error) {
+	return newTLV(tag, nil, 0, 0, TLVEmpty)
+}
+
+// Uint8 returns 1-octet integer value.
+func (t *Field) Uint8() (v uint8, err error) {
+	if err = t.check(1, 1, TLVUint8); err == nil {
+		v = t.Data[0]
+	}
+	return
+}
+
+// Uint16 returns 2-octet integer value.
+func (t *Field) Uint16() (v uint16, err error) {
+	if err = t.check(2, 2, TLVUint16); err == nil {
+		v = binary.BigEndian.Uint16(t.Data)
+	}
+	return
+}
+
+// Uint32 returns 4-octet integer value.
+func (t *Field) Uint32() (v uint32, err error) {
+	if err = t.check(4, 4, TLVUint32); err == nil {
+		v = binary.BigEndian.Uint32(t.Data)
+	}
+	return
+}
+
+// CString returns NULL terminated string value, without terminator.
+//
+// Value which is not NULL terminated is tolerated, since some SMSC(s) omit terminator.
+func (t *Field) CString() (v string, err error) {
+	if err = t.check(1, 0xFFFF, TLVCString); err == nil {
+		v = t.String()
+	}
+	return
+}
+
+// OctetString returns raw octet string value, also for composite parameters.
+func (t *Field) OctetString() (v []byte, err error) {
+	if err = t.check(0, 0xFFFF, TLVOctetString, TLVComposite); err == nil {
+		v = t.Data
+	}
+	return
+}
+
+// CallbackNum is value of callback_num TLV.
+type CallbackNum struct {
+	// DigitMode: 0 = TBCD, 1 = ASCII.
+	DigitMode byte
+	Ton       byte
+	Npi       byte
+	Digits    string
+}
+
+// NewTLVCallbackNum returns callback_num optional parameter.
+func NewTLVCallbackNum(v CallbackNum) (Field, error) {
+	value := append([]byte{v.DigitMode, v.Ton, v.Npi}, v.Digits...)
+	return newTLV(TagCallbackNum, value, 4, 19, TLVComposite)
+}
+
+// CallbackNum returns callback_num value.
+func (t *Field) CallbackNum() (v CallbackNum, err error) {
+	if err = t.check(4, 19, TLVComposite); err == nil {
+		v = CallbackNum{DigitMode: t.Data[0], Ton: t.Data[1], Npi: t.Data[2], Digits: string(t.Data[3:])}
+	}
+	return
+}
+
+// CallbackNumAtag is value of callback_num_atag TLV, alphanumeric display tag of call back number.
+type CallbackNumAtag struct {
+	DataCoding byte
+	Display    []byte
+}
+
+// NewTLVCallbackNumAtag returns callback_num_atag optional parameter.
+func NewTLVCallbackNumAtag(v CallbackNumAtag) (Field, error) {
+	return newTLV(TagCallbackNumAtag, append([]byte{v.DataCoding}, v.Display...), 1, 65, TLVComposite)
+}
+
+// CallbackNumAtag returns callback_num_atag value.
+func (t *Field) CallbackNumAtag() (v CallbackNumAtag, err error) {
+	if err = t.check(1, 65, TLVComposite); err == nil {
+		v = CallbackNumAtag{DataCoding: t.Data[0], Display: t.Data[1:]}
+	}
+	return
+}
+
+// Subaddress is value of source_subaddress and dest_subaddress TLVs.
+type Subaddress struct {
+	// Type: 0x80 = NSAP (Even), 0x88 = NSAP (Odd), 0xA0 = User Specified.
+	Type    byte
+	Address []byte
+}
+
+// NewTLVSubaddress returns source_subaddress or dest_subaddress optional parameter.
+func NewTLVSubaddress(tag Tag, v Subaddress) (Field, error) {
+	return newTLV(tag, append([]byte{v.Type}, v.Address...), 2, 23, TLVComposite)
+}
+
+// Subaddress returns source_subaddress or dest_subaddress value.
+func (t *Field) Subaddress() (v Subaddress, err error) {
+	if err = t.check(2, 23, TLVComposite); err == nil {
+		v = Subaddress{Type: t.Data[0], Address: t.Data[1:]}
+	}
+	return
+}
+
+// NewTLVNetworkErrorCode returns network_error_code optional parameter.
+func NewTLVNetworkErrorCode(v NetworkErrorCode) (Field, error) {
+	value := binary.BigEndian.AppendUint16([]byte{v.NetworkType}, v.ErrorCode)
+	return newTLV(TagNetworkErrorCode, value, 3, 3, TLVComposite)
+}
+
+// NetworkErrorCode returns network_error_code value.
+func (t *Field) NetworkErrorCode() (v NetworkErrorCode, err error) {
+	if err = t.check(3, 3, TLVComposite); err == nil {
+		v = NetworkErrorCode{NetworkType: t.Data[0], ErrorCode: binary.BigEndian.Uint16(t.Data[1:])}
+	}
+	return
+}
+
+// ItsSessionInfo is value of its_session_info TLV.
+type ItsSessionInfo struct {
+	SessionNumber byte
+	// SequenceNumber is 7-bit sequence number within session.
+	SequenceNumber byte
+	EndOfSession   bool
+}
+
+// NewTLVItsSessionInfo returns its_session_info optional parameter.
+func NewTLVItsSessionInfo(v ItsSessionInfo) (Field, error) {
+	seq := v.SequenceNumber << 1
+	if v.EndOfSession {
+		seq |= 0x01
+	}
+	return newTLV(TagItsSessionInfo, []byte{v.SessionNumber, seq}, 2, 2, TLVComposite)
+}
+
+// ItsSessionInfo returns its_session_info value.
+func (t *Field) ItsSessionInfo() (v ItsSessionInfo, err error) {
+	if err = t.check(2, 2, TLVComposite); err == nil {
+		v = ItsSessionInfo{
+			SessionNumber:  t.Data[0],
+			SequenceNumber: t.Data[1] >> 1,
+			EndOfSession:   t.Data[1]&0x01 > 0,
+		}
+	}
+	return
+}
+
+// BroadcastAreaIdentifier is value of broadcast_area_identifier TLV.
+type BroadcastAreaIdentifier struct {
+	// Format: 0x00 = alias/name, 0x01 = ellipsoid arc, 0x02 = polygon.
+	Format  byte
+	Details []byte
+}
+
+// NewTLVBroadcastAreaIdentifier returns broadcast_area_identifier optional parameter.
+func NewTLVBroadcastAreaIdentifier(v BroadcastAreaIdentifier) (Field, error) {
+	return newTLV(TagBroadcastAreaIdentifier, append([]byte{v.Format}, v.Details...), 1, 101, TLVComposite)
+}
+
+// BroadcastAreaIdentifier returns broadcast_area_identifier value.
+func (t *Field) BroadcastAreaIdentifier() (v BroadcastAreaIdentifier, err error) {
+	if err = t.check(1, 101, TLVComposite); err == nil {
+		v = BroadcastAreaIdentifier{Format: t.Data[0], Details: t.Data[1:]}
+	}
+	return
+}
+
+// BroadcastContentType is value of broadcast_content_type TLV.
+type BroadcastContentType struct {
+	// NetworkType: 0 = generic, 1 = GSM, 2 = TDMA, 3 = CDMA.
+	NetworkType byte
+	// ContentType: service type of broadcast content, e.g. 0x0000 = index.
+	ContentType uint16
+}
+
+// NewTLVBroadcastContentType returns broadcast_content_type optional parameter.
+func NewTLVBroadcastContentType(v BroadcastContentType) (Field, error) {
+	value := binary.BigEndian.AppendUint16([]byte{v.NetworkType}, v.ContentType)
+	return newTLV(TagBroadcastContentType, value, 3, 3, TLVComposite)
+}
+
+// BroadcastContentType returns broadcast_content_type value.
+func (t *Field) BroadcastContentType() (v BroadcastContentType, err error) {
+	if err = t.check(3, 3, TLVComposite); err == nil {
+		v = BroadcastContentType{NetworkType: t.Data[0], ContentType: binary.BigEndian.Uint16(t.Data[1:])}
+	}
+	return
+}
+
+// BroadcastFrequencyInterval is value of broadcast_frequency_interval TLV.
+type BroadcastFrequencyInterval struct {
+	// Unit: 0x00 = as frequently as possible, 0x08 = seconds, 0x09 = minutes, 0x0A = hours,
+	// 0x0B = days, 0x0C = weeks, 0x0D = months, 0x0E = years.
+	Unit  byte
+	Value uint16
+}
+
+// NewTLVBroadcastFrequencyInterval returns broadcast_frequency_interval optional parameter.
+func NewTLVBroadcastFrequencyInterval(v BroadcastFrequencyInterval) (Field, error) {
+	value := binary.BigEndian.AppendUint16([]byte{v.Unit}, v.Value)
+	return newTLV(TagBroadcastFrequencyInterval, value, 3, 3, TLVComposite)
+}
+
+// BroadcastFrequencyInterval returns broadcast_frequency_interval value.
+func (t *Field) BroadcastFrequencyInterval() (v BroadcastFrequencyInterval, err error) {
+	if err = t.check(3, 3, TLVComposite); err == nil {
+		v = BroadcastFrequencyInterval{Unit: t.Data[0], Value: binary.BigEndian.Uint16(t.Data[1:])}
+	}
+	return
+}
+
+// getTLV returns optional parameter of given tag.
+func (c *base) getTLV(tag Tag) (f Field, err error) {
+	f, ok := c.OptionalParameters[tag]
+	if !ok {
+		err = fmt.Errorf("%w: tag 0x%s", ErrTLVNotFound, tag.Hex())
+	}
+	return
+}
+
+// setTLV registers valid optional parameter.
+func (c *base) setTLV(f Field, err error) error {
+	if err == nil {
+		c.RegisterOptionalParam(f)
+	}
+	return err
+}
+
+// HasTLV reports whether optional parameter of given tag is present, e.g. alert_on_message_delivery.
+func (c *base) HasTLV(tag Tag) bool {
+	_, ok := c.OptionalParameters[tag]
+	return ok
+}
+
+// GetTLVUint8 returns 1-octet integer value of optional parameter, e.g. message_state.
+func (c *base) GetTLVUint8(tag Tag) (uint8, error) {
+	f, err := c.getTLV(tag)
+	if err != nil {
+		return 0, err
+	}
+	return f.Uint8()
+}
+
+// SetTLVUint8 sets 1-octet integer optional parameter.
+func (c *base) SetTLVUint8(tag Tag, v uint8) error {
+	return c.setTLV(NewTLVUint8(tag, v))
+}
+
+// GetTLVUint16 returns 2-octet integer value of optional parameter, e.g. sar_msg_ref_num.
+func (c *base) GetTLVUint16(tag Tag) (uint16, error) {
+	f, err := c.getTLV(tag)
+	if err != nil {
+		return 0, err
+	}
+	return f.Uint16()
+}
+
+// SetTLVUint16 sets 2-octet integer optional parameter.
+func (c *base) SetTLVUint16(tag Tag, v uint16) error {
+	return c.setTLV(NewTLVUint16(tag, v))
+}
+
+// GetTLVUint32 returns 4-octet integer value of optional parameter, e.g. qos_time_to_live.
+func (c *base) GetTLVUint32(tag Tag) (uint32, error) {
+	f, err := c.getTLV(tag)
+	if err != nil {
+		return 0, err
+	}
+	return f.Uint32()
+}
+
+// SetTLVUint32 sets 4-octet integer optional parameter.
+func (c *base) SetTLVUint32(tag Tag, v uint32) error {
+	return c.setTLV(NewTLVUint32(tag, v))
+}
+
+// GetTLVCString returns NULL terminated string value of optional parameter, e.g. receipted_message_id.
+func (c *base) GetTLVCString(tag Tag) (string, error) {
+	f, err := c.getTLV(tag)
+	if err != nil {
+		return "", err
+	}
+	return f.CString()
+}
+
+// SetTLVCString sets NULL terminated string optional parameter.
+func (c *base) SetTLVCString(tag Tag, v string) error {
+	return c.setTLV(NewTLVCString(tag, v))
+}
+
+// GetTLVOctetString returns octet string value of optional parameter, e.g. message_payload.
+func (c *base) GetTLVOctetString(tag Tag) ([]byte, error) {
+	f, err := c.getTLV(tag)
+	if err != nil {
+		return nil, err
+	}
+	return f.OctetString()
+}
+
+// SetTLVOctetString sets octet string optional parameter.
+func (c *base) SetTLVOctetString(tag Tag, v []byte) error {
+	return c.setTLV(NewTLVOctetString(tag, v))
+}
+
+// GetTLVCallbackNum returns value of callback_num optional parameter.
+func (c *base) GetTLVCallbackNum() (CallbackNum, error) {
+	f, err := c.getTLV(TagCallbackNum)
+	if err != nil {
+		return CallbackNum{}, err
+	}
+	return f.CallbackNum()
+}
+
+// SetTLVCallbackNum sets callback_num optional parameter.
+func (c *base) SetTLVCallbackNum(v CallbackNum) error {
+	return c.setTLV(NewTLVCallbackNum(v))
+}
+
+// GetTLVNetworkErrorCode returns value of network_error_code optional parameter.
+func (c *base) GetTLVNetworkErrorCode() (NetworkErrorCode, error) {
+	f, err := c.getTLV(TagNetworkErrorCode)
+	if err != nil {
+		return NetworkErrorCode{}, err
+	}
+	return f.NetworkErrorCode()
+}
+
+// SetTLVNetworkErrorCode sets network_error_code optional parameter.
+func (c *base) SetTLVNetworkErrorCode(v NetworkErrorCode) error {
+	return c.setTLV(NewTLVNetworkErrorCode(v))
+}
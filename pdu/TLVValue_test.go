@@ -0,0 +1,196 @@
+package pdu
+
+import (
+	"testing"
+
+	"github.com/linxGnu/gosmpp/data"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTLVValue(t *testing.T) {
+	t.Run("integers", func(t *testing.T) {
+		f, err := NewTLVUint8(TagMessageState, data.SM_STATE_DELIVERED)
+		require.NoError(t, err)
+		require.Equal(t, []byte{data.SM_STATE_DELIVERED}, f.Data)
+		v8, err := f.Uint8()
+		require.NoError(t, err)
+		require.EqualValues(t, data.SM_STATE_DELIVERED, v8)
+
+		f, err = NewTLVUint16(TagSarMsgRefNum, 0x0102)
+		require.NoError(t, err)
+		require.Equal(t, []byte{0x01, 0x02}, f.Data)
+		v16, err := f.Uint16()
+		require.NoError(t, err)
+		require.EqualValues(t, 0x0102, v16)
+
+		f, err = NewTLVUint32(TagQosTimeToLive, 3600)
+		require.NoError(t, err)
+		require.Equal(t, "00000e10", toHex(f.Data))
+		v32, err := f.Uint32()
+		require.NoError(t, err)
+		require.EqualValues(t, 3600, v32)
+	})
+
+	t.Run("strings", func(t *testing.T) {
+		f, err := NewTLVCString(TagReceiptedMessageID, "abc")
+		require.NoError(t, err)
+		require.Equal(t, []byte("abc\x00"), f.Data)
+		s, err := f.CString()
+		require.NoError(t, err)
+		require.Equal(t, "abc", s)
+
+		_, err = NewTLVCString(TagReceiptedMessageID, string(make([]byte, 65)))
+		require.ErrorIs(t, err, ErrTLVLength)
+
+		f, err = NewTLVOctetString(TagMessagePayload, []byte{0x01, 0x02})
+		require.NoError(t, err)
+		b, err := f.OctetString()
+		require.NoError(t, err)
+		require.Equal(t, []byte{0x01, 0x02}, b)
+	})
+
+	t.Run("mismatch", func(t *testing.T) {
+		_, err := NewTLVUint16(TagMessageState, 1)
+		require.ErrorIs(t, err, ErrTLVType)
+
+		f := Field{Tag: TagMessageState, Data: []byte{0x01, 0x02}}
+		_, err = f.Uint8()
+		require.ErrorIs(t, err, ErrTLVLength)
+		require.ErrorIs(t, f.Validate(), ErrTLVLength)
+
+		_, err = f.CString()
+		require.ErrorIs(t, err, ErrTLVType)
+	})
+
+	t.Run("vendorSpecific", func(t *testing.T) {
+		f, err := NewTLVUint16(0x1400, 7)
+		require.NoError(t, err)
+		require.NoError(t, f.Validate())
+
+		v, err := f.Uint16()
+		require.NoError(t, err)
+		require.EqualValues(t, 7, v)
+
+		_, err = f.Uint8()
+		require.ErrorIs(t, err, ErrTLVLength)
+
+		typ, _, _, ok := TLVSpec(0x1400)
+		require.False(t, ok)
+		require.Equal(t, TLVUnknown, typ)
+	})
+
+	t.Run("composite", func(t *testing.T) {
+		f, err := NewTLVCallbackNum(CallbackNum{DigitMode: 1, Ton: 1, Npi: 1, Digits: "84901234567"})
+		require.NoError(t, err)
+		require.Equal(t, "0101013834393031323334353637", toHex(f.Data))
+		cb, err := f.CallbackNum()
+		require.NoError(t, err)
+		require.Equal(t, "84901234567", cb.Digits)
+
+		_, err = NewTLVCallbackNum(CallbackNum{})
+		require.ErrorIs(t, err, ErrTLVLength)
+
+		f, err = NewTLVCallbackNumAtag(CallbackNumAtag{DataCoding: data.GSM7BITCoding, Display: []byte("Help")})
+		require.NoError(t, err)
+		atag, err := f.CallbackNumAtag()
+		require.NoError(t, err)
+		require.Equal(t, []byte("Help"), atag.Display)
+
+		f, err = NewTLVSubaddress(TagDestSubaddress, Subaddress{Type: 0xA0, Address: []byte{0x12, 0x34}})
+		require.NoError(t, err)
+		require.Equal(t, "a01234", toHex(f.Data))
+		sub, err := f.Subaddress()
+		require.NoError(t, err)
+		require.EqualValues(t, 0xA0, sub.Type)
+
+		f, err = NewTLVNetworkErrorCode(NetworkErrorCode{NetworkType: 3, ErrorCode: 0x0102})
+		require.NoError(t, err)
+		require.Equal(t, "030102", toHex(f.Data))
+		code, err := f.NetworkErrorCode()
+		require.NoError(t, err)
+		require.Equal(t, NetworkErrorCode{NetworkType: 3, ErrorCode: 0x0102}, code)
+
+		f, err = NewTLVItsSessionInfo(ItsSessionInfo{SessionNumber: 5, SequenceNumber: 3, EndOfSession: true})
+		require.NoError(t, err)
+		require.Equal(t, "0507", toHex(f.Data))
+		info, err := f.ItsSessionInfo()
+		require.NoError(t, err)
+		require.Equal(t, ItsSessionInfo{SessionNumber: 5, SequenceNumber: 3, EndOfSession: true}, info)
+
+		// composite value as raw octets
+		b, err := f.OctetString()
+		require.NoError(t, err)
+		require.Equal(t, []byte{0x05, 0x07}, b)
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		f, err := NewTLVEmpty(TagAlertOnMessageDelivery)
+		require.NoError(t, err)
+
+		b := NewBuffer(nil)
+		f.Marshal(b)
+		require.Equal(t, "130c0000", toHex(b.Bytes()))
+
+		// field without value of other types is omitted
+		b = NewBuffer(nil)
+		(&Field{Tag: TagReceiptedMessageID}).Marshal(b)
+		require.Zero(t, b.Len())
+	})
+}
+
+func TestBaseTLV(t *testing.T) {
+	p := NewDeliverSM().(*DeliverSM)
+
+	_, err := p.GetTLVUint8(TagMessageState)
+	require.ErrorIs(t, err, ErrTLVNotFound)
+	require.False(t, p.HasTLV(TagMessageState))
+
+	require.NoError(t, p.SetTLVUint8(TagMessageState, data.SM_STATE_EXPIRED))
+	require.NoError(t, p.SetTLVUint16(TagUserMessageReference, 513))
+	require.NoError(t, p.SetTLVUint32(TagQosTimeToLive, 60))
+	require.NoError(t, p.SetTLVCString(TagReceiptedMessageID, "id"))
+	require.NoError(t, p.SetTLVOctetString(TagMessagePayload, []byte("hi")))
+	require.NoError(t, p.SetTLVCallbackNum(CallbackNum{Digits: "1234"}))
+	require.NoError(t, p.SetTLVNetworkErrorCode(NetworkErrorCode{NetworkType: 3, ErrorCode: 1}))
+	require.ErrorIs(t, p.SetTLVUint8(TagSarMsgRefNum, 1), ErrTLVType)
+
+	alert, _ := NewTLVEmpty(TagAlertOnMessageDelivery)
+	p.RegisterOptionalParam(alert)
+
+	// round trip
+	b := NewBuffer(nil)
+	p.Marshal(b)
+	parsed, err := Parse(b)
+	require.NoError(t, err)
+	q := parsed.(*DeliverSM)
+	require.True(t, q.HasTLV(TagAlertOnMessageDelivery))
+
+	state, err := q.GetTLVUint8(TagMessageState)
+	require.NoError(t, err)
+	require.EqualValues(t, data.SM_STATE_EXPIRED, state)
+
+	ref, err := q.GetTLVUint16(TagUserMessageReference)
+	require.NoError(t, err)
+	require.EqualValues(t, 513, ref)
+
+	ttl, err := q.GetTLVUint32(TagQosTimeToLive)
+	require.NoError(t, err)
+	require.EqualValues(t, 60, ttl)
+
+	id, err := q.GetTLVCString(TagReceiptedMessageID)
+	require.NoError(t, err)
+	require.Equal(t, "id", id)
+
+	payload, err := q.GetTLVOctetString(TagMessagePayload)
+	require.NoError(t, err)
+	require.Equal(t, []byte("hi"), payload)
+
+	cb, err := q.GetTLVCallbackNum()
+	require.NoError(t, err)
+	require.Equal(t, "1234", cb.Digits)
+
+	code, err := q.GetTLVNetworkErrorCode()
+	require.NoError(t, err)
+	require.EqualValues(t, 3, code.NetworkType)
+}
@@ -8,6 +8,7 @@ import (
 )
 
 // For now, this package only support message uses of UDH for message concatenation
+// and national language shift tables
 // No plan for supporting other Enhanced Messaging Service
 // Credit to https://github.com/warthog618/sms
 
@@ -165,6 +166,48 @@ func (u UDH) GetConcatInfo() (totalParts, partNum, mref byte, found bool) {
 	return
 }
 
+// GetConcatRef returns the FIRST concatenated message IE, either with 8-bit or 16-bit reference number.
+func (u UDH) GetConcatRef() (mref uint16, totalParts, partNum byte, found bool) {
+	ie, found := u.concatIE()
+	switch {
+	case !found:
+		return
+
+	case ie.ID == data.UDH_CONCAT_MSG_8_BIT_REF:
+		return uint16(ie.Data[0]), ie.Data[1], ie.Data[2], true
+
+	default:
+		return uint16(ie.Data[0])<<8 | uint16(ie.Data[1]), ie.Data[2], ie.Data[3], true
+	}
+}
+
+// concatIE returns the FIRST well-formed concatenated message IE, either with 8-bit or 16-bit reference number.
+func (u UDH) concatIE() (ie InfoElement, found bool) {
+	for i := range u {
+		switch ie = u[i]; {
+		case ie.ID == data.UDH_CONCAT_MSG_8_BIT_REF && len(ie.Data) == 3,
+			ie.ID == data.UDH_CONCAT_MSG_16_BIT_REF && len(ie.Data) == 4:
+			return ie, true
+		}
+	}
+	return InfoElement{}, false
+}
+
+// GetNationalLanguages returns national languages of locking shift and single shift tables,
+// signalled by national language IEs. Languages without IE are data.NationalLanguageDefault.
+func (u UDH) GetNationalLanguages() (locking, single data.NationalLanguage, found bool) {
+	for i := range u {
+		switch ie := u[i]; {
+		case ie.ID == data.UDH_NATIONAL_LOCKING_SHIFT && len(ie.Data) == 1:
+			locking, found = data.NationalLanguage(ie.Data[0]), true
+
+		case ie.ID == data.UDH_NATIONAL_SINGLE_SHIFT && len(ie.Data) == 1:
+			single, found = data.NationalLanguage(ie.Data[0]), true
+		}
+	}
+	return
+}
+
 // InfoElement represent a 3 parts Information-Element
 // as defined in 3GPP TS 23.040 Section 9.2.3.24
 // Each InfoElement is comprised of it's identifier and data
@@ -182,6 +225,30 @@ func NewIEConcatMessage(totalParts, partNum, mref byte) InfoElement {
 	}
 }
 
+// NewIEConcatMessage16 turn a new IE element for concat message info with 16-bit reference number
+func NewIEConcatMessage16(totalParts, partNum byte, mref uint16) InfoElement {
+	return InfoElement{
+		ID:   data.UDH_CONCAT_MSG_16_BIT_REF,
+		Data: []byte{byte(mref >> 8), byte(mref), totalParts, partNum},
+	}
+}
+
+// NewIENationalLockingShift turn a new IE element for national language locking shift table
+func NewIENationalLockingShift(lang data.NationalLanguage) InfoElement {
+	return InfoElement{
+		ID:   data.UDH_NATIONAL_LOCKING_SHIFT,
+		Data: []byte{byte(lang)},
+	}
+}
+
+// NewIENationalSingleShift turn a new IE element for national language single shift table
+func NewIENationalSingleShift(lang data.NationalLanguage) InfoElement {
+	return InfoElement{
+		ID:   data.UDH_NATIONAL_SINGLE_SHIFT,
+		Data: []byte{byte(lang)},
+	}
+}
+
 // UnmarshalBinary unmarshal IE from binary in src, only read a single IE,
 // expect src at least of length 2 with correct IE format:
 //
@@ -3,6 +3,8 @@ package pdu
 import (
 	"testing"
 
+	"github.com/linxGnu/gosmpp/data"
+
 	"github.com/stretchr/testify/require"
 )
 
@@ -27,6 +29,36 @@ func TestUserDataHeader(t *testing.T) {
 		require.Equal(t, reference, uint8(12))
 	})
 
+	t.Run("getConcatRef", func(t *testing.T) {
+		ref, totalParts, partNum, found := UDH{NewIEConcatMessage(2, 1, 12)}.GetConcatRef()
+		require.True(t, found)
+		require.Equal(t, []uint16{12, 2, 1}, []uint16{ref, uint16(totalParts), uint16(partNum)})
+
+		ref, totalParts, partNum, found = UDH{{ID: data.UDH_CONCAT_MSG_16_BIT_REF, Data: []byte{0x01, 0x02, 3, 2}}}.GetConcatRef()
+		require.True(t, found)
+		require.Equal(t, []uint16{0x0102, 3, 2}, []uint16{ref, uint16(totalParts), uint16(partNum)})
+
+		_, _, _, found = UDH{{ID: data.UDH_CONCAT_MSG_16_BIT_REF, Data: []byte{0x01, 0x02, 3}}}.GetConcatRef()
+		require.False(t, found)
+	})
+
+	t.Run("getNationalLanguages", func(t *testing.T) {
+		locking, single, found := UDH{
+			NewIEConcatMessage(2, 1, 12),
+			NewIENationalSingleShift(data.NationalLanguageSpanish),
+		}.GetNationalLanguages()
+		require.True(t, found)
+		require.Equal(t, data.NationalLanguageDefault, locking)
+		require.Equal(t, data.NationalLanguageSpanish, single)
+
+		b, err := UDH{NewIENationalLockingShift(data.NationalLanguagePortuguese)}.MarshalBinary()
+		require.NoError(t, err)
+		require.Equal(t, []byte{0x03, 0x25, 0x01, 0x03}, b)
+
+		_, _, found = UDH{NewIEConcatMessage(2, 1, 12)}.GetNationalLanguages()
+		require.False(t, found)
+	})
+
 	t.Run("unmarshalBinaryUDHConcatMessage", func(t *testing.T) {
 		u, rd := new(UDH), []byte{0x05, 0x00, 0x03, 0x0c, 0x02, 0x01}
 		read, err := u.UnmarshalBinary(rd)
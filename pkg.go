@@ -1,9 +1,11 @@
 package gosmpp
 
 import (
+	"context"
 	"io"
 	"time"
 
+	"github.com/linxGnu/gosmpp/data"
 	"github.com/linxGnu/gosmpp/pdu"
 )
 
@@ -11,6 +13,9 @@ import (
 type Transceiver interface {
 	io.Closer
 	Submit(pdu.PDU) error
+	SubmitContext(context.Context, pdu.PDU) error
+	SubmitAsync(pdu.PDU) (*ResponseFuture, error)
+	SubmitAndWait(context.Context, pdu.PDU) (pdu.PDU, error)
 	SystemID() string
 }
 
@@ -18,6 +23,9 @@ type Transceiver interface {
 type Transmitter interface {
 	io.Closer
 	Submit(pdu.PDU) error
+	SubmitContext(context.Context, pdu.PDU) error
+	SubmitAsync(pdu.PDU) (*ResponseFuture, error)
+	SubmitAndWait(context.Context, pdu.PDU) (pdu.PDU, error)
 	SystemID() string
 }
 
@@ -73,16 +81,58 @@ type Settings struct {
 	// OnRebindingError notifies error while rebinding.
 	OnRebindingError ErrorCallback
 
+	// OnRebindingGiveUp notifies that Session gave up rebinding and is closed, along with
+	// error of the last attempt. See WithBackoffPolicy.
+	OnRebindingGiveUp ErrorCallback
+
 	// OnClosed notifies `closed` event due to State.
 	OnClosed ClosedCallback
 
 	// OnRebind notifies `rebind` event due to State.
+	//
+	// SMSC endpoint of the new bind is returned by Session.Endpoint, e.g. when using FailoverConnector.
 	OnRebind RebindCallback
 
+	// RateLimit throttles outbound requests, i.e. to comply with
+	// throughput (TPS) capped by SMSC.
+	//
+	// Nil disables rate limiting.
+	RateLimit *RateLimit
+
+	// Encodings overrides encodings of data_coding values for received short messages,
+	// e.g. LATIN1 for 0x00 if SMSC default alphabet is ISO-8859-1.
+	//
+	// Nil falls back to encodings registered by data.RegisterEncoding.
+	Encodings data.Encodings
+
 	// SMPP Bind Window tracking feature config
 	*WindowedRequestTracking
 
 	response func(pdu.PDU)
+
+	// onSequenceAssigned notifies that request is assigned a sequence number, right before writing.
+	onSequenceAssigned func(pdu.PDU)
+
+	// onResponse consumes response PDU which is awaited by a ResponseFuture.
+	onResponse func(pdu.PDU) (consumed bool)
+
+	// onWindowFreed notifies that request(s) are removed from the request window.
+	onWindowFreed func()
+
+	// onCongestionState notifies congestion_state TLV carried within received PDU.
+	onCongestionState func(state byte)
+
+	// onReceived notifies every PDU read from connection.
+	onReceived func(pdu.PDU)
+
+	// retry schedules re-submitting of request due to its response.
+	retry func(Request, pdu.PDU) (scheduled bool)
+}
+
+func (s *Settings) notifyWindowFreed() {
+	if s.onWindowFreed != nil {
+		s.onWindowFreed()
+	}
 }
 
 // WindowedRequestTracking settings for TX (transmitter) and TRX (transceiver) request store.
@@ -139,6 +189,13 @@ type WindowedRequestTracking struct {
 	// if enabled, EnquireLink and Unbind request will be responded to automatically
 	EnableAutoRespond bool
 
+	// RetryPolicy re-submits requests which are responded with temporary failure,
+	// i.e. ESME_RTHROTTLED, ESME_RMSGQFUL. Retried responses are not passed to OnExpectedPduResponse,
+	// OnAllPDU nor OnPDU, whichever handles responses.
+	//
+	// Nil disables retry.
+	RetryPolicy *RetryPolicy
+
 	// Set the time duration to expire a request for storing or retrieving data from request store
 	//
 	// Value must be greater than 0
@@ -0,0 +1,216 @@
+package gosmpp
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+
+	"github.com/linxGnu/gosmpp/pdu"
+)
+
+var (
+	// ErrEmptySessionPool indicates SessionPool is created without session.
+	ErrEmptySessionPool = errors.New("session pool requires at least one session")
+
+	// ErrNoAvailableSession indicates all sessions of SessionPool are rebinding or closed.
+	ErrNoAvailableSession = errors.New("no available session in pool")
+
+	// ErrSessionPoolClosed indicates SessionPool is closed.
+	ErrSessionPoolClosed = errors.New("session pool is closed")
+)
+
+// BalanceStrategy decides which session of SessionPool a PDU is submitted through.
+type BalanceStrategy byte
+
+const (
+	// RoundRobin submits through available sessions in turn.
+	RoundRobin BalanceStrategy = iota
+
+	// LeastOutstanding submits through available session with the fewest requests waiting for
+	// responses, i.e. the smallest request window if WindowedRequestTracking is set.
+	LeastOutstanding
+)
+
+// SessionPoolStats represents aggregated state of SessionPool.
+type SessionPoolStats struct {
+	// Sessions is the number of sessions in pool.
+	Sessions int
+
+	// Bound is the number of sessions available for submitting.
+	Bound int
+
+	// Rebinding is the number of sessions which are (re)binding.
+	Rebinding int
+
+	// Closed is the number of closed sessions.
+	Closed int
+
+	// WindowSize is the total size of request windows of sessions.
+	WindowSize int
+
+	// Outstanding is the total number of requests waiting for responses.
+	Outstanding int
+}
+
+// SessionPool balances submitted PDU(s) among multiple sessions, e.g. several binds to the same SMSC.
+//
+// Sessions which are rebinding or closed are skipped. Pool owns its sessions and closes them on Close.
+type SessionPool struct {
+	strategy BalanceStrategy
+
+	mu       sync.RWMutex
+	sessions []*Session
+	closed   bool
+
+	next uint32
+}
+
+// NewSessionPool creates pool over given sessions, which might use different connectors.
+func NewSessionPool(strategy BalanceStrategy, sessions ...*Session) (*SessionPool, error) {
+	if len(sessions) == 0 {
+		return nil, ErrEmptySessionPool
+	}
+	return &SessionPool{
+		strategy: strategy,
+		sessions: append([]*Session(nil), sessions...),
+	}, nil
+}
+
+// Add a session to pool.
+func (p *SessionPool) Add(session *Session) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.closed {
+		return ErrSessionPoolClosed
+	}
+	p.sessions = append(p.sessions, session)
+	return nil
+}
+
+// Sessions returns sessions of pool.
+func (p *SessionPool) Sessions() []*Session {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	return append([]*Session(nil), p.sessions...)
+}
+
+// Submit a PDU through a session chosen by balance strategy.
+func (p *SessionPool) Submit(pd pdu.PDU) error {
+	s, err := p.pick()
+	if err != nil {
+		return err
+	}
+	return s.Transmitter().Submit(pd)
+}
+
+// SubmitContext submits a PDU through a session chosen by balance strategy. See Session.SubmitContext.
+func (p *SessionPool) SubmitContext(ctx context.Context, pd pdu.PDU) error {
+	s, err := p.pick()
+	if err != nil {
+		return err
+	}
+	return s.SubmitContext(ctx, pd)
+}
+
+// SubmitAsync submits a PDU through a session chosen by balance strategy. See Session.SubmitAsync.
+func (p *SessionPool) SubmitAsync(pd pdu.PDU) (*ResponseFuture, error) {
+	s, err := p.pick()
+	if err != nil {
+		return nil, err
+	}
+	return s.SubmitAsync(pd)
+}
+
+// SubmitAndWait submits a PDU through a session chosen by balance strategy. See Session.SubmitAndWait.
+func (p *SessionPool) SubmitAndWait(ctx context.Context, pd pdu.PDU) (pdu.PDU, error) {
+	s, err := p.pick()
+	if err != nil {
+		return nil, err
+	}
+	return s.SubmitAndWait(ctx, pd)
+}
+
+// Stats returns aggregated state of sessions.
+func (p *SessionPool) Stats() (stats SessionPoolStats) {
+	for _, s := range p.Sessions() {
+		stats.Sessions++
+
+		switch s.State() {
+		case SessionBound:
+			stats.Bound++
+			if size, err := s.bound().GetWindowSize(); err == nil {
+				stats.WindowSize += size
+			}
+			stats.Outstanding += s.bound().outstanding()
+
+		case SessionClosed:
+			stats.Closed++
+
+		default:
+			stats.Rebinding++
+		}
+	}
+	return
+}
+
+// GetWindowSize returns the total size of request windows of sessions.
+func (p *SessionPool) GetWindowSize() int {
+	return p.Stats().WindowSize
+}
+
+// Close pool and all of its sessions.
+func (p *SessionPool) Close() (err error) {
+	p.mu.Lock()
+	sessions := p.sessions
+	p.closed = true
+	p.mu.Unlock()
+
+	for _, s := range sessions {
+		if e := s.Close(); e != nil && err == nil {
+			err = e
+		}
+	}
+	return
+}
+
+// pick returns an available session according to balance strategy.
+func (p *SessionPool) pick() (*Session, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	if p.closed {
+		return nil, ErrSessionPoolClosed
+	}
+
+	n := len(p.sessions)
+	switch p.strategy {
+	case LeastOutstanding:
+		var (
+			picked *Session
+			least  int
+		)
+		for _, s := range p.sessions {
+			if s.available() {
+				if outstanding := s.bound().outstanding(); picked == nil || outstanding < least {
+					picked, least = s, outstanding
+				}
+			}
+		}
+		if picked != nil {
+			return picked, nil
+		}
+
+	default:
+		start := int(atomic.AddUint32(&p.next, 1) - 1)
+		for i := 0; i < n; i++ {
+			if s := p.sessions[(start+i)%n]; s.available() {
+				return s, nil
+			}
+		}
+	}
+
+	return nil, ErrNoAvailableSession
+}
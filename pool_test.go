@@ -0,0 +1,151 @@
+package gosmpp
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/linxGnu/gosmpp/pdu"
+
+	"github.com/stretchr/testify/require"
+)
+
+// newPoolTestServer returns a server counting received submit_sm(s), which are responded
+// unless silent is set.
+func newPoolTestServer(t *testing.T, received *int32, silent bool) (*Server, string) {
+	return newTestServer(t, ServerSettings{
+		SessionSettings: func(*ServerSession) Settings {
+			return Settings{
+				ReadTimeout: 2 * time.Second,
+				OnAllPDU: func(p pdu.PDU) (pdu.PDU, bool) {
+					if _, ok := p.(*pdu.SubmitSM); ok {
+						atomic.AddInt32(received, 1)
+						if silent {
+							return nil, false
+						}
+					}
+					return p.GetResponse(), false
+				},
+			}
+		},
+	})
+}
+
+func newPoolTestSession(t *testing.T, addr string) *Session {
+	session, err := NewSession(
+		TRXConnector(NonTLSDialer, Auth{SMSC: addr, SystemID: "esme", Password: "secret"}),
+		Settings{ReadTimeout: 2 * time.Second}, -1)
+	require.Nil(t, err)
+	return session
+}
+
+func TestSessionPool(t *testing.T) {
+	_, err := NewSessionPool(RoundRobin)
+	require.Equal(t, ErrEmptySessionPool, err)
+
+	var receivedA, receivedB int32
+
+	serverA, addrA := newPoolTestServer(t, &receivedA, false)
+	defer func() {
+		_ = serverA.Close()
+	}()
+	serverB, addrB := newPoolTestServer(t, &receivedB, false)
+	defer func() {
+		_ = serverB.Close()
+	}()
+
+	t.Run("RoundRobin", func(t *testing.T) {
+		atomic.StoreInt32(&receivedA, 0)
+		atomic.StoreInt32(&receivedB, 0)
+
+		pool, err := NewSessionPool(RoundRobin, newPoolTestSession(t, addrA), newPoolTestSession(t, addrB))
+		require.Nil(t, err)
+		defer func() {
+			_ = pool.Close()
+		}()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		defer cancel()
+
+		for i := 0; i < 10; i++ {
+			resp, err := pool.SubmitAndWait(ctx, newSubmitSM("esme"))
+			require.Nil(t, err)
+			require.IsType(t, &pdu.SubmitSMResp{}, resp)
+		}
+		require.EqualValues(t, 5, atomic.LoadInt32(&receivedA))
+		require.EqualValues(t, 5, atomic.LoadInt32(&receivedB))
+
+		require.Equal(t, SessionPoolStats{Sessions: 2, Bound: 2}, pool.Stats())
+	})
+
+	t.Run("SkipClosed", func(t *testing.T) {
+		atomic.StoreInt32(&receivedA, 0)
+		atomic.StoreInt32(&receivedB, 0)
+
+		sessionA := newPoolTestSession(t, addrA)
+		pool, err := NewSessionPool(RoundRobin, sessionA)
+		require.Nil(t, err)
+		require.Nil(t, pool.Add(newPoolTestSession(t, addrB)))
+		require.Len(t, pool.Sessions(), 2)
+
+		require.Nil(t, sessionA.Close())
+		for i := 0; i < 4; i++ {
+			require.Nil(t, pool.Submit(newSubmitSM("esme")))
+		}
+		require.Eventually(t, func() bool {
+			return atomic.LoadInt32(&receivedB) == 4
+		}, time.Second, 10*time.Millisecond)
+		require.EqualValues(t, 0, atomic.LoadInt32(&receivedA))
+		require.Equal(t, SessionPoolStats{Sessions: 2, Bound: 1, Closed: 1}, pool.Stats())
+
+		require.Nil(t, pool.Close())
+		require.Equal(t, ErrSessionPoolClosed, pool.Submit(newSubmitSM("esme")))
+		require.Equal(t, ErrSessionPoolClosed, pool.Add(newPoolTestSession(t, addrB)))
+	})
+
+	t.Run("NoAvailableSession", func(t *testing.T) {
+		session := newPoolTestSession(t, addrA)
+		pool, err := NewSessionPool(LeastOutstanding, session)
+		require.Nil(t, err)
+
+		require.Nil(t, session.Close())
+		require.Equal(t, ErrNoAvailableSession, pool.Submit(newSubmitSM("esme")))
+	})
+}
+
+func TestSessionPoolLeastOutstanding(t *testing.T) {
+	var receivedSilent, received int32
+
+	silent, silentAddr := newPoolTestServer(t, &receivedSilent, true)
+	defer func() {
+		_ = silent.Close()
+	}()
+	server, addr := newPoolTestServer(t, &received, false)
+	defer func() {
+		_ = server.Close()
+	}()
+
+	pool, err := NewSessionPool(LeastOutstanding, newPoolTestSession(t, silentAddr), newPoolTestSession(t, addr))
+	require.Nil(t, err)
+	defer func() {
+		_ = pool.Close()
+	}()
+
+	// first request is never responded, keeping one outstanding request on first session
+	_, err = pool.SubmitAsync(newSubmitSM("esme"))
+	require.Nil(t, err)
+	require.Equal(t, 1, pool.Stats().Outstanding)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	for i := 0; i < 5; i++ {
+		_, err = pool.SubmitAndWait(ctx, newSubmitSM("esme"))
+		require.Nil(t, err)
+	}
+	require.EqualValues(t, 5, atomic.LoadInt32(&received))
+	require.Eventually(t, func() bool {
+		return atomic.LoadInt32(&receivedSilent) == 1
+	}, time.Second, 10*time.Millisecond)
+}
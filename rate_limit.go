@@ -0,0 +1,202 @@
+package gosmpp
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/linxGnu/gosmpp/pdu"
+)
+
+var (
+	// ErrInvalidRateLimit indicates RateLimit.Rate is not positive.
+	ErrInvalidRateLimit = errors.New("RateLimit.Rate must be greater than 0")
+)
+
+// RateLimit settings for outbound PDU(s), i.e. throughput capped by SMSC contract.
+//
+// Rate limiting is applied to requests (submit_sm, data_sm, query_sm, ...) only.
+// EnquireLink and responses are never delayed: requests wait for rate limiter within
+// Submit/SubmitContext, before being queued for writing.
+//
+// Rate is reduced automatically while SMSC reports nearing congestion (90 and above) within
+// congestion_state TLV of SMPP 5.0, and restored as soon as congestion is relieved.
+type RateLimit struct {
+	// Rate is the number of PDU(s) allowed to be sent per second.
+	Rate float64
+
+	// Burst is the maximum number of PDU(s) which could be sent at once.
+	//
+	// Zero value is treated as 1.
+	Burst int
+
+	// OnWait notifies duration a PDU waited for rate limiter before being sent.
+	//
+	// Handle is optional and only triggered when PDU actually waited.
+	OnWait func(p pdu.PDU, waited time.Duration)
+}
+
+// RateLimitStats represents statistics of outbound rate limiter.
+type RateLimitStats struct {
+	// Throttled is the number of PDU(s) which had to wait.
+	Throttled uint64
+
+	// TotalWait is the sum of waiting durations.
+	TotalWait time.Duration
+
+	// MaxWait is the longest waiting duration.
+	MaxWait time.Duration
+
+	// CongestionState is the latest congestion_state reported by SMSC.
+	CongestionState byte
+
+	// Rate is the current number of PDU(s) allowed to be sent per second,
+	// which is lower than configured one while SMSC is congested.
+	Rate float64
+}
+
+const (
+	// congestionThreshold is congestion_state from which send rate is reduced,
+	// i.e. above optimum load (80-89).
+	congestionThreshold = 90
+
+	// minCongestionRatio is the lowest fraction of configured rate kept while SMSC is congested.
+	minCongestionRatio = 0.1
+)
+
+// congestedRate returns send rate adapted to congestion_state reported by SMSC.
+func congestedRate(rate float64, state byte) float64 {
+	if state < congestionThreshold {
+		return rate
+	}
+
+	ratio := float64(100-min(state, 100)) / 20
+	return rate * max(ratio, minCongestionRatio)
+}
+
+// rateLimiter is a token bucket rate limiter.
+type rateLimiter struct {
+	settings *RateLimit
+
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+	stats  RateLimitStats
+}
+
+func newRateLimiter(settings *RateLimit) *rateLimiter {
+	burst := float64(settings.Burst)
+	if burst < 1 {
+		burst = 1
+	}
+	return &rateLimiter{
+		settings: settings,
+		rate:     settings.Rate,
+		burst:    burst,
+		tokens:   burst,
+		last:     time.Now(),
+	}
+}
+
+// reserve takes a token and returns duration to wait until the token is available.
+func (r *rateLimiter) reserve(now time.Time) (wait time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.refill(now)
+
+	r.tokens--
+	if r.tokens < 0 {
+		wait = time.Duration(-r.tokens / r.rate * float64(time.Second))
+
+		r.stats.Throttled++
+		r.stats.TotalWait += wait
+		if wait > r.stats.MaxWait {
+			r.stats.MaxWait = wait
+		}
+	}
+	return
+}
+
+// release gives back a token taken by reserve, when PDU is not sent eventually.
+func (r *rateLimiter) release(now time.Time) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.refill(now)
+
+	r.tokens++
+	if r.tokens > r.burst {
+		r.tokens = r.burst
+	}
+}
+
+func (r *rateLimiter) refill(now time.Time) {
+	if elapsed := now.Sub(r.last); elapsed > 0 {
+		r.tokens += elapsed.Seconds() * r.rate
+		if r.tokens > r.burst {
+			r.tokens = r.burst
+		}
+		r.last = now
+	}
+}
+
+// congest adapts send rate to congestion_state reported by SMSC.
+func (r *rateLimiter) congest(state byte) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.refill(time.Now())
+	r.rate = congestedRate(r.settings.Rate, state)
+	r.stats.CongestionState = state
+}
+
+// wait blocks until PDU is allowed to be sent, context is done or done channel is closed.
+func (r *rateLimiter) wait(ctx context.Context, done <-chan struct{}, p pdu.PDU) error {
+	if !isRateLimited(p) {
+		return nil
+	}
+
+	wait := r.reserve(time.Now())
+	if wait <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+	case <-ctx.Done():
+		r.release(time.Now())
+		return ctx.Err()
+	case <-done:
+		r.release(time.Now())
+		return ErrConnectionClosing
+	}
+
+	if r.settings.OnWait != nil {
+		r.settings.OnWait(p, wait)
+	}
+	return nil
+}
+
+// Stats returns statistics of rate limiter.
+func (r *rateLimiter) Stats() (stats RateLimitStats) {
+	r.mu.Lock()
+	stats = r.stats
+	stats.Rate = r.rate
+	r.mu.Unlock()
+	return
+}
+
+func isRateLimited(p pdu.PDU) bool {
+	if !p.CanResponse() {
+		return false
+	}
+	_, ok := p.(*pdu.EnquireLink)
+	return !ok
+}
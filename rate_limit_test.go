@@ -0,0 +1,218 @@
+package gosmpp
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/linxGnu/gosmpp/pdu"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateLimiter(t *testing.T) {
+	r := newRateLimiter(&RateLimit{Rate: 10, Burst: 2})
+	now := r.last
+
+	// burst
+	require.Zero(t, r.reserve(now))
+	require.Zero(t, r.reserve(now))
+
+	// exceeded
+	require.Equal(t, 100*time.Millisecond, r.reserve(now))
+	require.Equal(t, 200*time.Millisecond, r.reserve(now))
+
+	// released by cancelled wait
+	r.release(now)
+	require.Equal(t, 200*time.Millisecond, r.reserve(now))
+
+	// refilled
+	require.Zero(t, r.reserve(now.Add(time.Second)))
+
+	stats := r.Stats()
+	require.EqualValues(t, 3, stats.Throttled)
+	require.Equal(t, 500*time.Millisecond, stats.TotalWait)
+	require.Equal(t, 200*time.Millisecond, stats.MaxWait)
+
+	require.True(t, isRateLimited(pdu.NewSubmitSM()))
+	require.False(t, isRateLimited(pdu.NewEnquireLink()))
+	require.False(t, isRateLimited(pdu.NewSubmitSMResp()))
+}
+
+func TestCongestedRate(t *testing.T) {
+	require.EqualValues(t, 100, congestedRate(100, 0))
+	require.EqualValues(t, 100, congestedRate(100, 89))
+	require.EqualValues(t, 50, congestedRate(100, 90))
+	require.EqualValues(t, 10, congestedRate(100, 99))
+	require.EqualValues(t, 10, congestedRate(100, 100))
+	require.EqualValues(t, 10, congestedRate(100, 255))
+
+	r := newRateLimiter(&RateLimit{Rate: 10})
+	r.congest(90)
+	require.EqualValues(t, 5, r.Stats().Rate)
+	require.EqualValues(t, 90, r.Stats().CongestionState)
+
+	r.congest(0)
+	require.EqualValues(t, 10, r.Stats().Rate)
+}
+
+func TestSessionRateLimit(t *testing.T) {
+	var received, throttled int32
+
+	server, addr := newTestServer(t, ServerSettings{
+		SessionSettings: func(*ServerSession) Settings {
+			return Settings{
+				ReadTimeout: 2 * time.Second,
+				OnPDU: func(p pdu.PDU, _ bool) {
+					if _, ok := p.(*pdu.SubmitSM); ok {
+						atomic.AddInt32(&received, 1)
+					}
+				},
+			}
+		},
+	})
+	defer func() {
+		_ = server.Close()
+	}()
+
+	_, err := NewSession(
+		TRXConnector(NonTLSDialer, Auth{SMSC: addr}),
+		Settings{
+			ReadTimeout: 2 * time.Second,
+			RateLimit:   &RateLimit{},
+		}, -1)
+	require.ErrorIs(t, err, ErrInvalidRateLimit)
+
+	session, err := NewSession(
+		TRXConnector(NonTLSDialer, Auth{SMSC: addr}),
+		Settings{
+			ReadTimeout: 2 * time.Second,
+			RateLimit: &RateLimit{
+				Rate:  50,
+				Burst: 5,
+				OnWait: func(_ pdu.PDU, waited time.Duration) {
+					require.Greater(t, waited, time.Duration(0))
+					atomic.AddInt32(&throttled, 1)
+				},
+			},
+		}, -1)
+	require.Nil(t, err)
+	defer func() {
+		_ = session.Close()
+	}()
+
+	start := time.Now()
+	for i := 0; i < 15; i++ {
+		require.Nil(t, session.Transceiver().Submit(pdu.NewSubmitSM()))
+	}
+
+	require.Eventually(t, func() bool {
+		return atomic.LoadInt32(&received) == 15
+	}, 2*time.Second, 5*time.Millisecond)
+
+	// 5 burst + 10 more at 50/s
+	require.GreaterOrEqual(t, time.Since(start), 180*time.Millisecond)
+	require.GreaterOrEqual(t, atomic.LoadInt32(&throttled), int32(8))
+	require.EqualValues(t, atomic.LoadInt32(&throttled), session.RateLimitStats().Throttled)
+}
+
+func TestSessionRateLimitCancelledWait(t *testing.T) {
+	server, addr := newTestServer(t, ServerSettings{
+		SessionSettings: func(*ServerSession) Settings {
+			return Settings{ReadTimeout: 2 * time.Second}
+		},
+	})
+	defer func() {
+		_ = server.Close()
+	}()
+
+	session, err := NewSession(
+		TRXConnector(NonTLSDialer, Auth{SMSC: addr}),
+		Settings{
+			ReadTimeout: 2 * time.Second,
+			RateLimit:   &RateLimit{Rate: 10},
+		}, -1)
+	require.Nil(t, err)
+	defer func() {
+		_ = session.Close()
+	}()
+
+	trx := session.Transceiver()
+	require.Nil(t, trx.Submit(pdu.NewSubmitSM()))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	require.ErrorIs(t, trx.SubmitContext(ctx, pdu.NewSubmitSM()), context.DeadlineExceeded)
+
+	// cancelled submit gave its token back, next one waits for a single token only
+	start := time.Now()
+	require.Nil(t, trx.Submit(pdu.NewSubmitSM()))
+	require.Less(t, time.Since(start), 150*time.Millisecond)
+}
+
+func TestSessionRateLimitNotDelayingResponses(t *testing.T) {
+	server, addr := newTestServer(t, ServerSettings{
+		SessionSettings: func(*ServerSession) Settings {
+			return Settings{ReadTimeout: 2 * time.Second}
+		},
+	})
+	defer func() {
+		_ = server.Close()
+	}()
+
+	session, err := NewSession(
+		TRXConnector(NonTLSDialer, Auth{SMSC: addr}),
+		Settings{
+			ReadTimeout: 5 * time.Second,
+			RateLimit:   &RateLimit{Rate: 0.5},
+		}, -1)
+	require.Nil(t, err)
+
+	require.Eventually(t, func() bool {
+		return len(server.Sessions()) == 1
+	}, time.Second, 10*time.Millisecond)
+
+	// first request takes the only token, second one waits for 2 seconds
+	require.Nil(t, session.Transceiver().Submit(pdu.NewSubmitSM()))
+	throttled := make(chan error, 1)
+	go func() {
+		throttled <- session.Transceiver().Submit(pdu.NewSubmitSM())
+	}()
+	time.Sleep(50 * time.Millisecond)
+
+	// deliver_sm_resp is not queued behind throttled submit_sm
+	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+	defer cancel()
+	resp, err := server.Sessions()[0].SubmitAndWait(ctx, pdu.NewDeliverSM())
+	require.Nil(t, err)
+	require.IsType(t, &pdu.DeliverSMResp{}, resp)
+
+	// close releases throttled submitter at once
+	start := time.Now()
+	require.Nil(t, session.Close())
+	select {
+	case err = <-throttled:
+		require.ErrorIs(t, err, ErrConnectionClosing)
+	case <-time.After(time.Second):
+		t.Fatal("throttled submit was not released")
+	}
+	require.Less(t, time.Since(start), time.Second)
+
+	// context is honored while waiting for rate limiter
+	session, err = NewSession(
+		TRXConnector(NonTLSDialer, Auth{SMSC: addr}),
+		Settings{
+			ReadTimeout: 5 * time.Second,
+			RateLimit:   &RateLimit{Rate: 0.5},
+		}, -1)
+	require.Nil(t, err)
+	defer func() {
+		_ = session.Close()
+	}()
+
+	require.Nil(t, session.Transceiver().Submit(pdu.NewSubmitSM()))
+	ctx, cancel = context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	require.ErrorIs(t, session.SubmitContext(ctx, pdu.NewSubmitSM()), context.DeadlineExceeded)
+}
@@ -80,7 +80,7 @@ func (t *receivable) loop() {
 		// read pdu from conn
 		var p pdu.PDU
 		if err = t.conn.SetReadTimeout(t.settings.ReadTimeout); err == nil {
-			p, err = pdu.Parse(t.conn)
+			p, err = pdu.ParseWithEncodings(t.conn, t.settings.Encodings)
 		}
 		if err != nil {
 			if atomic.LoadInt32(&t.aliveState) == Alive {
@@ -92,10 +92,34 @@ func (t *receivable) loop() {
 			return
 		}
 
+		if p != nil && t.settings.onReceived != nil {
+			t.settings.onReceived(p)
+		}
+
+		if p != nil && t.settings.onCongestionState != nil {
+			if g, ok := p.(tlvGetter); ok {
+				if state, e := g.GetTLVUint8(pdu.TagCongestionState); e == nil {
+					t.settings.onCongestionState(state)
+				}
+			}
+		}
+
+		if p != nil && !p.CanResponse() && t.settings.onResponse != nil && t.settings.onResponse(p) {
+			if t.settings.WindowedRequestTracking != nil {
+				ctx, cancelFunc := context.WithTimeout(context.Background(), t.settings.StoreAccessTimeOut)
+				_ = t.requestStore.Delete(ctx, p.GetSequenceNumber())
+				cancelFunc()
+				t.settings.notifyWindowFreed()
+			}
+			continue
+		}
+
 		var closeOnUnbind bool
 		if p != nil {
 			if t.settings.WindowedRequestTracking != nil && t.settings.OnExpectedPduResponse != nil {
 				closeOnUnbind = t.handleWindowPdu(p)
+			} else if t.untrackResponse(p) {
+				continue // re-submitted by RetryPolicy
 			} else if t.settings.OnAllPDU != nil {
 				closeOnUnbind = t.handleAllPdu(p)
 			} else {
@@ -109,14 +133,57 @@ func (t *receivable) loop() {
 	}
 }
 
+// untrackResponse removes request of given response from request window, when responses are
+// handled by OnAllPDU or OnPDU. Returns true if the request is re-submitted instead.
+func (t *receivable) untrackResponse(p pdu.PDU) (retried bool) {
+	if t.settings.WindowedRequestTracking == nil || !isWindowedResponse(p) {
+		return
+	}
+
+	ctx, cancelFunc := context.WithTimeout(context.Background(), t.settings.StoreAccessTimeOut)
+	defer cancelFunc()
+
+	request, ok := t.requestStore.Get(ctx, p.GetSequenceNumber())
+	if !ok {
+		return
+	}
+	_ = t.requestStore.Delete(ctx, p.GetSequenceNumber())
+	t.settings.notifyWindowFreed()
+
+	return t.settings.retry != nil && t.settings.retry(request, p)
+}
+
+// isWindowedResponse checks if given PDU responds to a request tracked by request window.
+func isWindowedResponse(p pdu.PDU) bool {
+	// This case must match the same request item list in transmittable write func
+	switch p.(type) {
+	case *pdu.CancelSMResp,
+		*pdu.BroadcastSMResp,
+		*pdu.CancelBroadcastSMResp,
+		*pdu.DataSMResp,
+		*pdu.DeliverSMResp,
+		*pdu.EnquireLinkResp,
+		*pdu.QueryBroadcastSMResp,
+		*pdu.QuerySMResp,
+		*pdu.ReplaceSMResp,
+		*pdu.SubmitMultiResp,
+		*pdu.SubmitSMResp:
+		return true
+	}
+	return false
+}
+
 func (t *receivable) handleWindowPdu(p pdu.PDU) (closing bool) {
 	if t.settings.WindowedRequestTracking != nil && t.settings.OnExpectedPduResponse != nil && p != nil {
 		// This case must match the same request item list in transmittable write func
 		switch pp := p.(type) {
 		case *pdu.CancelSMResp,
+			*pdu.BroadcastSMResp,
+			*pdu.CancelBroadcastSMResp,
 			*pdu.DataSMResp,
 			*pdu.DeliverSMResp,
 			*pdu.EnquireLinkResp,
+			*pdu.QueryBroadcastSMResp,
 			*pdu.QuerySMResp,
 			*pdu.ReplaceSMResp,
 			*pdu.SubmitMultiResp,
@@ -127,6 +194,10 @@ func (t *receivable) handleWindowPdu(p pdu.PDU) (closing bool) {
 				request, ok := t.requestStore.Get(ctx, p.GetSequenceNumber())
 				if ok {
 					_ = t.requestStore.Delete(ctx, p.GetSequenceNumber())
+					t.settings.notifyWindowFreed()
+					if t.settings.retry != nil && t.settings.retry(request, p) {
+						return
+					}
 					response := Response{
 						PDU:             p,
 						OriginalRequest: request,
@@ -212,3 +283,8 @@ func (t *receivable) handleOrClose(p pdu.PDU) (closing bool) {
 	}
 	return
 }
+
+// tlvGetter is implemented by all PDU(s), to read their optional parameters.
+type tlvGetter interface {
+	GetTLVUint8(pdu.Tag) (uint8, error)
+}
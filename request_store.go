@@ -14,6 +14,9 @@ import (
 type Request struct {
 	pdu.PDU
 	TimeSent time.Time
+
+	// Retries is the number of times the request has been re-submitted by RetryPolicy.
+	Retries int
 }
 
 // Response represents a response from a Request in the RequestStore
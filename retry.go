@@ -0,0 +1,75 @@
+package gosmpp
+
+import (
+	"time"
+
+	"github.com/linxGnu/gosmpp/data"
+	"github.com/linxGnu/gosmpp/pdu"
+)
+
+// DefaultRetryableStatuses are command statuses which are retried by RetryPolicy
+// when RetryableStatuses is not set.
+var DefaultRetryableStatuses = []data.CommandStatusType{
+	data.ESME_RTHROTTLED,
+	data.ESME_RMSGQFUL,
+	data.ESME_RSYSERR,
+}
+
+// RetryPolicy re-submits requests whose responses carry temporary failure status.
+//
+// Original request is taken from RequestStore and re-submitted with a fresh sequence number
+// after exponential backoff. OnExpectedPduResponse is only triggered for the final response:
+// either a non-retryable one or the last one after attempts are exhausted. Likewise, futures
+// returned by SubmitAsync and SubmitAndWait complete with the final response.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of submissions of a request, including the first one.
+	//
+	// Value less than 2 disables retry.
+	MaxAttempts int
+
+	// InitialBackoff is the waiting duration before the first retry.
+	InitialBackoff time.Duration
+
+	// MaxBackoff caps the waiting duration between retries.
+	//
+	// Zero duration means no cap.
+	MaxBackoff time.Duration
+
+	// Multiplier is the factor by which backoff grows after each retry.
+	//
+	// Value less than 1 is treated as 2.
+	Multiplier float64
+
+	// Jitter randomly reduces backoff by up to this fraction, in range [0, 1].
+	Jitter float64
+
+	// RetryableStatuses are command statuses which should be retried.
+	//
+	// If not set, DefaultRetryableStatuses is used.
+	RetryableStatuses []data.CommandStatusType
+}
+
+// Backoff returns waiting duration before the given retry (starting from 0).
+func (r *RetryPolicy) Backoff(retry int) time.Duration {
+	return exponentialBackoff(r.InitialBackoff, r.MaxBackoff, r.Multiplier, r.Jitter, retry)
+}
+
+// Retryable checks if response status should be retried.
+func (r *RetryPolicy) Retryable(status data.CommandStatusType) bool {
+	statuses := r.RetryableStatuses
+	if len(statuses) == 0 {
+		statuses = DefaultRetryableStatuses
+	}
+
+	for _, s := range statuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
+
+// shouldRetry checks if request should be re-submitted due to its response.
+func (r *RetryPolicy) shouldRetry(request Request, resp pdu.PDU) bool {
+	return request.Retries+1 < r.MaxAttempts && r.Retryable(resp.GetHeader().CommandStatus)
+}
@@ -0,0 +1,274 @@
+package gosmpp
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/linxGnu/gosmpp/data"
+	"github.com/linxGnu/gosmpp/pdu"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	policy := &RetryPolicy{
+		MaxAttempts:    5,
+		InitialBackoff: 100 * time.Millisecond,
+		MaxBackoff:     time.Second,
+	}
+	require.Equal(t, 100*time.Millisecond, policy.Backoff(0))
+	require.Equal(t, 200*time.Millisecond, policy.Backoff(1))
+	require.Equal(t, 800*time.Millisecond, policy.Backoff(3))
+	require.Equal(t, time.Second, policy.Backoff(4))
+
+	policy.Jitter = 0.5
+	for i := 0; i < 100; i++ {
+		backoff := policy.Backoff(1)
+		require.GreaterOrEqual(t, backoff, 100*time.Millisecond)
+		require.LessOrEqual(t, backoff, 200*time.Millisecond)
+	}
+
+	require.True(t, policy.Retryable(data.ESME_RTHROTTLED))
+	require.True(t, policy.Retryable(data.ESME_RMSGQFUL))
+	require.False(t, policy.Retryable(data.ESME_RINVDSTADR))
+
+	policy.RetryableStatuses = []data.CommandStatusType{data.ESME_RINVDSTADR}
+	require.False(t, policy.Retryable(data.ESME_RTHROTTLED))
+	require.True(t, policy.Retryable(data.ESME_RINVDSTADR))
+}
+
+func TestRetryThrottledSubmission(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		attempts = map[string]int{}
+		seqs     = map[int32]struct{}{}
+	)
+
+	server, addr := newTestServer(t, ServerSettings{
+		SessionSettings: func(*ServerSession) Settings {
+			return Settings{
+				ReadTimeout: 2 * time.Second,
+				OnAllPDU: func(p pdu.PDU) (pdu.PDU, bool) {
+					pd, ok := p.(*pdu.SubmitSM)
+					if !ok {
+						return nil, false
+					}
+
+					msg, _ := pd.Message.GetMessage()
+
+					mu.Lock()
+					defer mu.Unlock()
+
+					seqs[pd.GetSequenceNumber()] = struct{}{}
+					attempts[msg]++
+
+					resp := pd.GetResponse().(*pdu.SubmitSMResp)
+					if msg == "always" || attempts[msg] < 3 {
+						resp.CommandStatus = data.ESME_RTHROTTLED
+					}
+					return resp, false
+				},
+			}
+		},
+	})
+	defer func() {
+		_ = server.Close()
+	}()
+
+	responses := make(chan Response, 2)
+	session, err := NewSession(
+		TRXConnector(NonTLSDialer, Auth{SMSC: addr}),
+		Settings{
+			ReadTimeout: 2 * time.Second,
+			WindowedRequestTracking: &WindowedRequestTracking{
+				MaxWindowSize:      10,
+				StoreAccessTimeOut: 100 * time.Millisecond,
+				OnExpectedPduResponse: func(r Response) {
+					responses <- r
+				},
+				RetryPolicy: &RetryPolicy{
+					MaxAttempts:    3,
+					InitialBackoff: 10 * time.Millisecond,
+				},
+			},
+		}, -1)
+	require.Nil(t, err)
+	defer func() {
+		_ = session.Close()
+	}()
+
+	for _, msg := range []string{"eventually", "always"} {
+		p := pdu.NewSubmitSM().(*pdu.SubmitSM)
+		_ = p.Message.SetMessageWithEncoding(msg, data.GSM7BIT)
+		require.Nil(t, session.Transceiver().Submit(p))
+	}
+
+	for i := 0; i < 2; i++ {
+		select {
+		case r := <-responses:
+			require.Equal(t, 2, r.OriginalRequest.Retries)
+
+			msg, _ := r.OriginalRequest.PDU.(*pdu.SubmitSM).Message.GetMessage()
+			if msg == "always" {
+				require.Equal(t, data.ESME_RTHROTTLED, r.PDU.GetHeader().CommandStatus)
+			} else {
+				require.True(t, r.PDU.IsOk())
+			}
+
+		case <-time.After(2 * time.Second):
+			t.Fatal("final response not received")
+		}
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.Equal(t, 3, attempts["eventually"])
+	require.Equal(t, 3, attempts["always"])
+	require.Len(t, seqs, 6) // fresh sequence number per attempt
+}
+
+func TestRetryAwaitedSubmission(t *testing.T) {
+	var attempts int32
+
+	server, addr := newTestServer(t, ServerSettings{
+		SessionSettings: func(*ServerSession) Settings {
+			return Settings{
+				ReadTimeout: 2 * time.Second,
+				OnAllPDU: func(p pdu.PDU) (pdu.PDU, bool) {
+					pd, ok := p.(*pdu.SubmitSM)
+					if !ok {
+						return nil, false
+					}
+
+					resp := pd.GetResponse().(*pdu.SubmitSMResp)
+					if atomic.AddInt32(&attempts, 1) < 3 {
+						resp.CommandStatus = data.ESME_RTHROTTLED
+					}
+					return resp, false
+				},
+			}
+		},
+	})
+	defer func() {
+		_ = server.Close()
+	}()
+
+	session, err := NewSession(
+		TRXConnector(NonTLSDialer, Auth{SMSC: addr}),
+		Settings{
+			ReadTimeout: 2 * time.Second,
+			WindowedRequestTracking: &WindowedRequestTracking{
+				MaxWindowSize:         10,
+				StoreAccessTimeOut:    100 * time.Millisecond,
+				OnExpectedPduResponse: func(Response) {},
+				RetryPolicy: &RetryPolicy{
+					MaxAttempts:    3,
+					InitialBackoff: 10 * time.Millisecond,
+				},
+			},
+		}, -1)
+	require.Nil(t, err)
+	defer func() {
+		_ = session.Close()
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	resp, err := session.SubmitAndWait(ctx, newSubmitSM(""))
+	require.Nil(t, err)
+	require.True(t, resp.IsOk())
+	require.EqualValues(t, 3, atomic.LoadInt32(&attempts))
+	require.Equal(t, 0, session.Transceiver().(*transceivable).outstanding())
+}
+
+func TestRetryWithoutExpectedPduResponse(t *testing.T) {
+	var attempts int32
+
+	server, addr := newTestServer(t, ServerSettings{
+		SessionSettings: func(*ServerSession) Settings {
+			return Settings{
+				ReadTimeout: 2 * time.Second,
+				OnAllPDU: func(p pdu.PDU) (pdu.PDU, bool) {
+					pd, ok := p.(*pdu.SubmitSM)
+					if !ok {
+						return nil, false
+					}
+
+					resp := pd.GetResponse().(*pdu.SubmitSMResp)
+					if atomic.AddInt32(&attempts, 1)%3 != 0 {
+						resp.CommandStatus = data.ESME_RTHROTTLED
+					}
+					return resp, false
+				},
+			}
+		},
+	})
+	defer func() {
+		_ = server.Close()
+	}()
+
+	newSettings := func() Settings {
+		return Settings{
+			ReadTimeout: 2 * time.Second,
+			WindowedRequestTracking: &WindowedRequestTracking{
+				MaxWindowSize:      10,
+				StoreAccessTimeOut: 100 * time.Millisecond,
+				RetryPolicy: &RetryPolicy{
+					MaxAttempts:    3,
+					InitialBackoff: 10 * time.Millisecond,
+				},
+			},
+		}
+	}
+
+	for name, handle := range map[string]func(Settings, chan<- pdu.PDU) Settings{
+		"OnAllPDU": func(settings Settings, responses chan<- pdu.PDU) Settings {
+			settings.OnAllPDU = func(p pdu.PDU) (pdu.PDU, bool) {
+				responses <- p
+				return nil, false
+			}
+			return settings
+		},
+		"OnPDU": func(settings Settings, responses chan<- pdu.PDU) Settings {
+			settings.OnPDU = func(p pdu.PDU, _ bool) {
+				responses <- p
+			}
+			return settings
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			responses := make(chan pdu.PDU, 3)
+
+			session, err := NewSession(
+				TRXConnector(NonTLSDialer, Auth{SMSC: addr}),
+				handle(newSettings(), responses), -1)
+			require.Nil(t, err)
+			defer func() {
+				_ = session.Close()
+			}()
+
+			require.Nil(t, session.Transceiver().Submit(newSubmitSM("")))
+
+			select {
+			case p := <-responses:
+				require.True(t, p.IsOk()) // throttled responses are retried, not handled
+			case <-time.After(2 * time.Second):
+				t.Fatal("final response not received")
+			}
+
+			size, err := session.GetWindowSize()
+			require.Nil(t, err)
+			require.Zero(t, size)
+
+			select {
+			case p := <-responses:
+				t.Fatalf("unexpected response: %v", p)
+			case <-time.After(50 * time.Millisecond):
+			}
+		})
+	}
+}
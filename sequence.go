@@ -0,0 +1,152 @@
+package gosmpp
+
+import (
+	"errors"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/linxGnu/gosmpp/data"
+	"github.com/linxGnu/gosmpp/pdu"
+)
+
+// ErrInvalidSequenceBlockSize indicates that block size of persistent sequence generator is not positive.
+var ErrInvalidSequenceBlockSize = errors.New("sequence block size must be positive")
+
+// SequenceGenerator generates sequence numbers of requests sent over a connection.
+//
+// Generated numbers must be in range 0x00000001 to 0x7FFFFFFF.
+type SequenceGenerator interface {
+	Next() (int32, error)
+}
+
+// NewSequenceGenerator returns in-memory SequenceGenerator, starting from 1.
+//
+// Safe for concurrent use.
+func NewSequenceGenerator() SequenceGenerator {
+	return &memorySequenceGenerator{}
+}
+
+type memorySequenceGenerator struct {
+	v int32
+}
+
+func (g *memorySequenceGenerator) Next() (int32, error) {
+	for {
+		current := atomic.LoadInt32(&g.v)
+		if atomic.CompareAndSwapInt32(&g.v, current, nextSequence(current)) {
+			return nextSequence(current), nil
+		}
+	}
+}
+
+func nextSequence(v int32) int32 {
+	if v <= 0 || v == math.MaxInt32 {
+		return 1
+	}
+	return v + 1
+}
+
+// FileSequenceGenerator is a SequenceGenerator persisting its state into a file,
+// so sequence numbers are not reused after restarts. Useful when requests are
+// correlated with responses via an external RequestStore.
+//
+// To avoid writing file for every number, blocks of numbers are reserved at once.
+// Unused numbers of the reserved block are skipped after restart.
+type FileSequenceGenerator struct {
+	mu        sync.Mutex
+	path      string
+	blockSize int32
+	current   int32
+	ceiling   int32
+}
+
+// NewFileSequenceGenerator returns FileSequenceGenerator which continues from
+// the state stored in given file. Missing file means starting from 1.
+func NewFileSequenceGenerator(path string, blockSize int32) (g *FileSequenceGenerator, err error) {
+	if blockSize <= 0 {
+		return nil, ErrInvalidSequenceBlockSize
+	}
+
+	g = &FileSequenceGenerator{
+		path:      path,
+		blockSize: blockSize,
+	}
+
+	content, err := os.ReadFile(path)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		err = nil
+
+	case err == nil:
+		var v int64
+		if v, err = strconv.ParseInt(strings.TrimSpace(string(content)), 10, 32); err != nil {
+			return nil, err
+		}
+		g.current, g.ceiling = int32(v), int32(v)
+
+	default:
+		return nil, err
+	}
+
+	return
+}
+
+// Next implements SequenceGenerator interface.
+func (g *FileSequenceGenerator) Next() (int32, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	next := nextSequence(g.current)
+	if next > g.ceiling || next == 1 {
+		ceiling := int64(next) + int64(g.blockSize) - 1
+		if ceiling > math.MaxInt32 {
+			ceiling = math.MaxInt32
+		}
+
+		if err := g.persist(int32(ceiling)); err != nil {
+			return 0, err
+		}
+		g.ceiling = int32(ceiling)
+	}
+
+	g.current = next
+	return next, nil
+}
+
+// persist writes reserved ceiling to file, atomically by renaming.
+func (g *FileSequenceGenerator) persist(ceiling int32) (err error) {
+	tmp, err := os.CreateTemp(filepath.Dir(g.path), filepath.Base(g.path)+".tmp*")
+	if err != nil {
+		return
+	}
+	defer func() {
+		if err != nil {
+			_ = os.Remove(tmp.Name())
+		}
+	}()
+
+	if _, err = tmp.WriteString(strconv.FormatInt(int64(ceiling), 10)); err != nil {
+		_ = tmp.Close()
+		return
+	}
+	if err = tmp.Sync(); err != nil {
+		_ = tmp.Close()
+		return
+	}
+	if err = tmp.Close(); err != nil {
+		return
+	}
+
+	return os.Rename(tmp.Name(), g.path)
+}
+
+// isRequest checks if PDU is a request, which takes a fresh sequence number.
+// Responses keep sequence number of their requests.
+func isRequest(p pdu.PDU) bool {
+	return p.GetHeader().CommandID&data.GENERIC_NACK == 0
+}
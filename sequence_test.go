@@ -0,0 +1,111 @@
+package gosmpp
+
+import (
+	"math"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/linxGnu/gosmpp/pdu"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSequenceGenerator(t *testing.T) {
+	g := NewSequenceGenerator()
+	for i := int32(1); i <= 3; i++ {
+		v, err := g.Next()
+		require.Nil(t, err)
+		require.Equal(t, i, v)
+	}
+
+	// wrap around
+	g = &memorySequenceGenerator{v: math.MaxInt32 - 1}
+	v, _ := g.Next()
+	require.EqualValues(t, math.MaxInt32, v)
+	v, _ = g.Next()
+	require.EqualValues(t, 1, v)
+}
+
+func TestFileSequenceGenerator(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "seq")
+
+	_, err := NewFileSequenceGenerator(path, 0)
+	require.ErrorIs(t, err, ErrInvalidSequenceBlockSize)
+
+	g, err := NewFileSequenceGenerator(path, 10)
+	require.Nil(t, err)
+	for i := int32(1); i <= 12; i++ {
+		v, err := g.Next()
+		require.Nil(t, err)
+		require.Equal(t, i, v)
+	}
+
+	content, err := os.ReadFile(path)
+	require.Nil(t, err)
+	require.Equal(t, "20", string(content))
+
+	// restart continues after reserved block
+	g, err = NewFileSequenceGenerator(path, 10)
+	require.Nil(t, err)
+	v, err := g.Next()
+	require.Nil(t, err)
+	require.EqualValues(t, 21, v)
+
+	// wrap around
+	require.Nil(t, os.WriteFile(path, []byte("2147483647"), 0o600))
+	g, err = NewFileSequenceGenerator(path, 10)
+	require.Nil(t, err)
+	v, err = g.Next()
+	require.Nil(t, err)
+	require.EqualValues(t, 1, v)
+}
+
+func TestSequencePerConnection(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		bindSeqs []int32
+	)
+
+	server, addr := newTestServer(t, ServerSettings{
+		SessionSettings: func(s *ServerSession) Settings {
+			mu.Lock()
+			bindSeqs = append(bindSeqs, s.BindRequest().GetSequenceNumber())
+			mu.Unlock()
+
+			return Settings{
+				ReadTimeout: 2 * time.Second,
+				OnPDU:       func(p pdu.PDU, _ bool) {},
+			}
+		},
+	})
+	defer func() {
+		_ = server.Close()
+	}()
+
+	shared := NewSequenceGenerator()
+	for _, connector := range []Connector{
+		TRXConnector(NonTLSDialer, Auth{SMSC: addr}),
+		TRXConnector(NonTLSDialer, Auth{SMSC: addr}),
+		TRXConnector(NonTLSDialer, Auth{SMSC: addr}, WithSequenceGenerator(shared)),
+		TRXConnector(NonTLSDialer, Auth{SMSC: addr}, WithSequenceGenerator(shared)),
+	} {
+		session, err := NewSession(connector, Settings{ReadTimeout: 2 * time.Second}, -1)
+		require.Nil(t, err)
+
+		req := pdu.NewSubmitSM()
+		req.SetSequenceNumber(100)
+		resp, err := session.Transceiver().SubmitAndWait(t.Context(), req)
+		require.Nil(t, err)
+		require.Equal(t, req.GetSequenceNumber(), resp.GetSequenceNumber())
+		require.NotEqual(t, int32(100), req.GetSequenceNumber())
+
+		_ = session.Close()
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.Equal(t, []int32{1, 1, 1, 4}, bindSeqs) // bind, submit_sm, unbind of the previous session
+}
@@ -0,0 +1,414 @@
+package gosmpp
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/linxGnu/gosmpp/data"
+	"github.com/linxGnu/gosmpp/pdu"
+)
+
+var (
+	// ErrServerClosed is returned by Server.Serve after Server.Close is called.
+	ErrServerClosed = errors.New("smpp server closed")
+
+	// ErrSessionSettingsNotSet indicates ServerSettings.SessionSettings is missing.
+	ErrSessionSettingsNotSet = errors.New("SessionSettings cannot be nil")
+)
+
+// BindAuthenticator validates bind request sent by ESME.
+//
+// Returned command status is sent back to ESME within bind_resp.
+// Any status other than data.ESME_ROK rejects the bind.
+type BindAuthenticator func(req *pdu.BindRequest) data.CommandStatusType
+
+// ServerSettings for SMSC side Server.
+type ServerSettings struct {
+	// SystemID is SMSC identifier which is sent to ESME within bind_resp.
+	SystemID string
+
+	// InterfaceVersion is sc_interface_version which is sent within bind_resp to ESME(s)
+	// supporting optional parameters. Bind interface version is the lower one of both sides.
+	//
+	// Zero defaults to data.SMPP_V34.
+	InterfaceVersion byte
+
+	// BindTimeout is the maximum duration Server waits for a bind request
+	// on a newly accepted connection.
+	//
+	// Zero duration disables the timeout.
+	BindTimeout time.Duration
+
+	// Authenticate validates bind requests.
+	//
+	// If not set, all bind requests are accepted.
+	Authenticate BindAuthenticator
+
+	// SessionSettings returns Settings for the session of a successfully authenticated ESME.
+	// The returned Settings are validated the same way NewSession does.
+	//
+	// Callbacks in returned Settings could capture the given ServerSession to submit PDU(s)
+	// back to ESME, but must not submit within SessionSettings itself.
+	SessionSettings func(*ServerSession) Settings
+
+	// OnAcceptError notifies error while accepting new connection.
+	OnAcceptError ErrorCallback
+
+	// OnBindError notifies error while waiting, authenticating or responding bind request.
+	OnBindError ErrorCallback
+}
+
+// ServerSession represents a bound ESME session on SMSC side.
+type ServerSession struct {
+	bindReq *pdu.BindRequest
+	conn    *Connection
+	trx     *transceivable
+	closed  bool // guarded by Server.mu
+}
+
+// SystemID returns system_id which ESME used to bind.
+func (s *ServerSession) SystemID() string {
+	return s.bindReq.SystemID
+}
+
+// BindingType returns binding type requested by ESME.
+func (s *ServerSession) BindingType() pdu.BindingType {
+	return s.bindReq.BindingType
+}
+
+// BindRequest returns bind request sent by ESME.
+func (s *ServerSession) BindRequest() *pdu.BindRequest {
+	return s.bindReq
+}
+
+// Capabilities returns features supported within the bind, according to interface version
+// negotiated with ESME.
+func (s *ServerSession) Capabilities() Capabilities {
+	return newCapabilities(s.conn.interfaceVersion)
+}
+
+// RemoteAddr returns ESME network address.
+func (s *ServerSession) RemoteAddr() net.Addr {
+	return s.conn.RemoteAddr()
+}
+
+// Submit a PDU to ESME.
+func (s *ServerSession) Submit(p pdu.PDU) error {
+	return s.trx.Submit(p)
+}
+
+// SubmitContext submits a PDU to ESME, blocking while request window is full.
+func (s *ServerSession) SubmitContext(ctx context.Context, p pdu.PDU) error {
+	return s.trx.SubmitContext(ctx, p)
+}
+
+// SubmitAsync submits a PDU to ESME and returns a future of its response.
+func (s *ServerSession) SubmitAsync(p pdu.PDU) (*ResponseFuture, error) {
+	return s.trx.SubmitAsync(p)
+}
+
+// SubmitAndWait submits a PDU to ESME and waits for its response until context is done.
+func (s *ServerSession) SubmitAndWait(ctx context.Context, p pdu.PDU) (pdu.PDU, error) {
+	return s.trx.SubmitAndWait(ctx, p)
+}
+
+// Close session and underlying connection.
+func (s *ServerSession) Close() error {
+	return s.trx.Close()
+}
+
+// Server accepts binds from ESME(s) and runs sessions as an SMSC.
+type Server struct {
+	settings ServerSettings
+
+	mu        sync.Mutex
+	listeners map[net.Listener]struct{}
+	sessions  map[*ServerSession]struct{}
+
+	state int32
+}
+
+// NewServer creates new SMSC side server.
+func NewServer(settings ServerSettings) (*Server, error) {
+	if settings.SessionSettings == nil {
+		return nil, ErrSessionSettingsNotSet
+	}
+
+	return &Server{
+		settings:  settings,
+		listeners: make(map[net.Listener]struct{}),
+		sessions:  make(map[*ServerSession]struct{}),
+	}, nil
+}
+
+// ListenAndServe listens on the TCP network address and then calls Serve.
+func (s *Server) ListenAndServe(addr string) error {
+	l, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+	return s.Serve(l)
+}
+
+// Serve accepts incoming connections on the listener, handling binds and creating
+// a session for each bound ESME.
+//
+// Serve always returns non-nil error and closes the listener.
+// After Server.Close, the returned error is ErrServerClosed.
+func (s *Server) Serve(l net.Listener) error {
+	if !s.trackListener(l, true) {
+		_ = l.Close()
+		return ErrServerClosed
+	}
+	defer s.trackListener(l, false)
+
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			if atomic.LoadInt32(&s.state) == Closed {
+				return ErrServerClosed
+			}
+
+			if s.settings.OnAcceptError != nil {
+				s.settings.OnAcceptError(err)
+			}
+
+			var nErr net.Error
+			if errors.As(err, &nErr) && nErr.Timeout() {
+				time.Sleep(5 * time.Millisecond)
+				continue
+			}
+
+			_ = l.Close()
+			return err
+		}
+
+		go s.handle(conn)
+	}
+}
+
+// Outbind dials ESME at given address and sends outbind with given credentials, then serves
+// bind_receiver expected over the same connection, as an accepted connection.
+func (s *Server) Outbind(dialer Dialer, addr, systemID, password string) (err error) {
+	if atomic.LoadInt32(&s.state) == Closed {
+		return ErrServerClosed
+	}
+
+	netConn, err := dialer(addr)
+	if err != nil {
+		return
+	}
+
+	conn := NewConnection(netConn)
+
+	outbind := pdu.NewOutbind().(*pdu.Outbind)
+	outbind.SystemID = systemID
+	outbind.Password = password
+
+	seq, err := conn.NextSequenceNumber()
+	if err == nil {
+		outbind.SetSequenceNumber(seq)
+		_, err = conn.WritePDU(outbind)
+	}
+	if err != nil {
+		_ = conn.Close()
+		return
+	}
+
+	go func() {
+		if err := s.bind(conn); err != nil {
+			if s.settings.OnBindError != nil {
+				s.settings.OnBindError(err)
+			}
+			_ = conn.Close()
+		}
+	}()
+	return
+}
+
+// Sessions returns currently bound sessions.
+func (s *Server) Sessions() (sessions []*ServerSession) {
+	s.mu.Lock()
+	sessions = make([]*ServerSession, 0, len(s.sessions))
+	for session := range s.sessions {
+		sessions = append(sessions, session)
+	}
+	s.mu.Unlock()
+	return
+}
+
+// Close server: stop all listeners and close all bound sessions.
+func (s *Server) Close() (err error) {
+	if atomic.CompareAndSwapInt32(&s.state, Alive, Closed) {
+		s.mu.Lock()
+		for l := range s.listeners {
+			if e := l.Close(); e != nil && err == nil {
+				err = e
+			}
+		}
+		s.mu.Unlock()
+
+		for _, session := range s.Sessions() {
+			_ = session.Close()
+		}
+	}
+	return
+}
+
+func (s *Server) trackListener(l net.Listener, add bool) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if add {
+		if atomic.LoadInt32(&s.state) == Closed {
+			return false
+		}
+		s.listeners[l] = struct{}{}
+	} else {
+		delete(s.listeners, l)
+	}
+	return true
+}
+
+func (s *Server) trackSession(session *ServerSession, add bool) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if add {
+		if atomic.LoadInt32(&s.state) == Closed {
+			return false
+		}
+		// session might be closed by ESME right after being started
+		if !session.closed {
+			s.sessions[session] = struct{}{}
+		}
+	} else {
+		session.closed = true
+		delete(s.sessions, session)
+	}
+	return true
+}
+
+func (s *Server) handle(netConn net.Conn) {
+	conn := NewConnection(netConn)
+
+	if err := s.bind(conn); err != nil {
+		if s.settings.OnBindError != nil {
+			s.settings.OnBindError(err)
+		}
+		_ = conn.Close()
+	}
+}
+
+func (s *Server) bind(conn *Connection) (err error) {
+	req, err := waitBindRequest(conn, s.settings.BindTimeout)
+	if err != nil {
+		return
+	}
+
+	resp := req.GetResponse().(*pdu.BindResp)
+	resp.SystemID = s.settings.SystemID
+
+	interfaceVersion := s.settings.InterfaceVersion
+	if interfaceVersion == 0 {
+		interfaceVersion = data.SMPP_V34
+	}
+	if req.InterfaceVersion >= data.SMPP_V34 {
+		_ = resp.SetTLVUint8(pdu.TagScInterfaceVersion, interfaceVersion)
+	}
+	conn.interfaceVersion = min(req.InterfaceVersion, interfaceVersion)
+
+	if s.settings.Authenticate != nil {
+		resp.CommandStatus = s.settings.Authenticate(req)
+	}
+
+	session := &ServerSession{
+		bindReq: req,
+		conn:    conn,
+	}
+
+	var settings Settings
+	if resp.CommandStatus == data.ESME_ROK {
+		settings = s.settings.SessionSettings(session)
+		if err = validateSettings(settings); err != nil {
+			resp.CommandStatus = data.ESME_RSYSERR
+		}
+	}
+
+	// reset read deadline used while waiting for bind request
+	if e := conn.SetReadDeadline(time.Time{}); e != nil && err == nil {
+		err = e
+	}
+
+	if _, e := conn.WritePDU(resp); e != nil && err == nil {
+		err = e
+	}
+
+	if err == nil && resp.CommandStatus != data.ESME_ROK {
+		err = BindError{CommandStatus: resp.CommandStatus}
+	}
+
+	if err != nil {
+		return
+	}
+
+	// tag connection with ESME's system_id
+	conn.systemID = req.SystemID
+
+	originalOnClosed := settings.OnClosed
+	settings.OnClosed = func(state State) {
+		s.trackSession(session, false)
+		if originalOnClosed != nil {
+			originalOnClosed(state)
+		}
+	}
+
+	var requestStore RequestStore
+	if settings.WindowedRequestTracking != nil {
+		requestStore = NewDefaultStore()
+	}
+
+	session.trx = newTransceivable(conn, settings, requestStore)
+	session.trx.start()
+	if !s.trackSession(session, true) {
+		_ = session.trx.Close()
+		return ErrServerClosed
+	}
+
+	return
+}
+
+// waitBindRequest reads PDU(s) from connection until a bind request comes.
+// Any other PDU is rejected with generic_nack(ESME_RINVBNDSTS).
+func waitBindRequest(conn *Connection, timeout time.Duration) (req *pdu.BindRequest, err error) {
+	if timeout > 0 {
+		if err = conn.SetReadTimeout(timeout); err != nil {
+			return
+		}
+	}
+
+	for {
+		var p pdu.PDU
+		if p, err = pdu.Parse(conn); err != nil {
+			return
+		}
+
+		if bindReq, ok := p.(*pdu.BindRequest); ok {
+			req = bindReq
+			return
+		}
+
+		if p.CanResponse() {
+			nack := pdu.NewGenericNack().(*pdu.GenericNack)
+			nack.CommandStatus = data.ESME_RINVBNDSTS
+			nack.SetSequenceNumber(p.GetSequenceNumber())
+			if _, err = conn.WritePDU(nack); err != nil {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,318 @@
+package gosmpp
+
+import (
+	"context"
+	"net"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/linxGnu/gosmpp/data"
+	"github.com/linxGnu/gosmpp/pdu"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newTestServer(t *testing.T, settings ServerSettings) (*Server, string) {
+	server, err := NewServer(settings)
+	require.Nil(t, err)
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.Nil(t, err)
+
+	go func() {
+		_ = server.Serve(l)
+	}()
+
+	return server, l.Addr().String()
+}
+
+func TestServer(t *testing.T) {
+	var receivedSubmitSM, receivedSubmitSMResp int32
+
+	server, addr := newTestServer(t, ServerSettings{
+		SystemID:    "smsc",
+		BindTimeout: time.Second,
+		Authenticate: func(req *pdu.BindRequest) data.CommandStatusType {
+			if req.SystemID == "esme" && req.Password == "secret" {
+				return data.ESME_ROK
+			}
+			return data.ESME_RINVPASWD
+		},
+		SessionSettings: func(session *ServerSession) Settings {
+			require.Equal(t, "esme", session.SystemID())
+			require.Equal(t, pdu.Transceiver, session.BindingType())
+			return Settings{
+				ReadTimeout: 2 * time.Second,
+				OnPDU: func(p pdu.PDU, responded bool) {
+					if _, ok := p.(*pdu.SubmitSM); ok {
+						require.True(t, responded)
+						atomic.AddInt32(&receivedSubmitSM, 1)
+					}
+				},
+			}
+		},
+	})
+	defer func() {
+		_ = server.Close()
+	}()
+
+	t.Run("InvalidPassword", func(t *testing.T) {
+		_, err := TRXConnector(NonTLSDialer, Auth{SMSC: addr, SystemID: "esme", Password: "wrong"}).Connect()
+		require.Equal(t, BindError{CommandStatus: data.ESME_RINVPASWD}, err)
+	})
+
+	t.Run("Bind", func(t *testing.T) {
+		session, err := NewSession(
+			TRXConnector(NonTLSDialer, Auth{SMSC: addr, SystemID: "esme", Password: "secret"}),
+			Settings{
+				ReadTimeout: 2 * time.Second,
+				OnPDU: func(p pdu.PDU, _ bool) {
+					if _, ok := p.(*pdu.SubmitSMResp); ok {
+						atomic.AddInt32(&receivedSubmitSMResp, 1)
+					}
+				},
+			}, -1)
+		require.Nil(t, err)
+		require.Equal(t, "smsc", session.Transceiver().SystemID())
+
+		require.Eventually(t, func() bool {
+			return len(server.Sessions()) == 1
+		}, time.Second, 10*time.Millisecond)
+
+		for i := 0; i < 5; i++ {
+			require.Nil(t, session.Transceiver().Submit(newSubmitSM("esme")))
+		}
+
+		require.Eventually(t, func() bool {
+			return atomic.LoadInt32(&receivedSubmitSM) == 5 && atomic.LoadInt32(&receivedSubmitSMResp) == 5
+		}, 2*time.Second, 10*time.Millisecond)
+
+		require.Nil(t, session.Close())
+		require.Eventually(t, func() bool {
+			return len(server.Sessions()) == 0
+		}, 2*time.Second, 10*time.Millisecond)
+	})
+
+	t.Run("Broadcast", func(t *testing.T) {
+		session, err := NewSession(
+			TRXConnector(NonTLSDialer, Auth{SMSC: addr, SystemID: "esme", Password: "secret"}),
+			Settings{ReadTimeout: 2 * time.Second}, -1)
+		require.Nil(t, err)
+		defer func() {
+			_ = session.Close()
+		}()
+
+		broadcast := pdu.NewBroadcastSM().(*pdu.BroadcastSM)
+		broadcast.AreaIdentifiers = []pdu.BroadcastAreaIdentifier{{Format: 0x00, Details: []byte("area")}}
+		require.Nil(t, broadcast.SetMessage("alert", data.GSM7BIT))
+		require.Nil(t, broadcast.SetTLVUint16(pdu.TagBroadcastRepNum, 1))
+
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		defer cancel()
+
+		resp, err := session.Transceiver().SubmitAndWait(ctx, broadcast)
+		require.Nil(t, err)
+		require.IsType(t, &pdu.BroadcastSMResp{}, resp)
+		require.Equal(t, broadcast.GetSequenceNumber(), resp.GetSequenceNumber())
+
+		resp, err = session.Transceiver().SubmitAndWait(ctx, pdu.NewCancelBroadcastSM())
+		require.Nil(t, err)
+		require.IsType(t, &pdu.CancelBroadcastSMResp{}, resp)
+	})
+}
+
+func TestServerInterfaceVersion(t *testing.T) {
+	newServer := func(version byte) string {
+		server, addr := newTestServer(t, ServerSettings{
+			SystemID:         "smsc",
+			InterfaceVersion: version,
+			SessionSettings: func(session *ServerSession) Settings {
+				return Settings{ReadTimeout: 2 * time.Second}
+			},
+		})
+		t.Cleanup(func() {
+			_ = server.Close()
+		})
+		return addr
+	}
+
+	check := func(t *testing.T, addr string, expected byte, opts ...connectorOption) {
+		session, err := NewSession(
+			TRXConnector(NonTLSDialer, Auth{SMSC: addr, SystemID: "esme"}, opts...),
+			Settings{ReadTimeout: 2 * time.Second}, -1)
+		require.Nil(t, err)
+		defer func() {
+			_ = session.Close()
+		}()
+
+		capabilities := session.Capabilities()
+		require.Equal(t, expected, capabilities.InterfaceVersion)
+		require.True(t, capabilities.OptionalParameters)
+		require.Equal(t, expected >= data.SMPP_V50, capabilities.Broadcast)
+		require.Equal(t, expected >= data.SMPP_V50, capabilities.CongestionState)
+	}
+
+	smpp34, smpp50 := newServer(0), newServer(data.SMPP_V50)
+
+	t.Run("Default", func(t *testing.T) {
+		check(t, smpp34, data.SMPP_V34)
+		check(t, smpp50, data.SMPP_V34)
+	})
+
+	t.Run("SMPP50", func(t *testing.T) {
+		check(t, smpp34, data.SMPP_V34, WithInterfaceVersion(data.SMPP_V50))
+		check(t, smpp50, data.SMPP_V50, WithInterfaceVersion(data.SMPP_V50))
+	})
+
+	t.Run("SMPP33", func(t *testing.T) {
+		resp := pdu.NewBindResp(*pdu.NewBindRequest(pdu.Transceiver))
+		require.Equal(t, byte(0x33), negotiateInterfaceVersion(data.SMPP_V34, resp))
+		require.False(t, newCapabilities(0x33).OptionalParameters)
+	})
+}
+
+func TestServerCongestionState(t *testing.T) {
+	server, addr := newTestServer(t, ServerSettings{
+		SystemID:         "smsc",
+		InterfaceVersion: data.SMPP_V50,
+		SessionSettings: func(session *ServerSession) Settings {
+			return Settings{
+				ReadTimeout: 2 * time.Second,
+				OnAllPDU: func(p pdu.PDU) (pdu.PDU, bool) {
+					resp := p.GetResponse()
+					if sm, ok := p.(*pdu.SubmitSM); ok {
+						state, _ := sm.GetTLVUint8(pdu.TagUserResponseCode) // congestion to report back
+						_ = resp.(*pdu.SubmitSMResp).SetTLVUint8(pdu.TagCongestionState, state)
+					}
+					return resp, false
+				},
+			}
+		},
+	})
+	defer func() {
+		_ = server.Close()
+	}()
+
+	session, err := NewSession(
+		TRXConnector(NonTLSDialer, Auth{SMSC: addr, SystemID: "esme"}, WithInterfaceVersion(data.SMPP_V50)),
+		Settings{
+			ReadTimeout: 2 * time.Second,
+			RateLimit:   &RateLimit{Rate: 100, Burst: 10},
+		}, -1)
+	require.Nil(t, err)
+	defer func() {
+		_ = session.Close()
+	}()
+
+	submit := func(state byte) {
+		sm := newSubmitSM("esme")
+		require.Nil(t, sm.SetTLVUint8(pdu.TagUserResponseCode, state))
+
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		defer cancel()
+
+		resp, err := session.SubmitAndWait(ctx, sm)
+		require.Nil(t, err)
+		require.IsType(t, &pdu.SubmitSMResp{}, resp)
+	}
+
+	submit(50)
+	require.EqualValues(t, 50, session.CongestionState())
+	require.EqualValues(t, 100, session.RateLimitStats().Rate)
+
+	submit(95)
+	require.EqualValues(t, 95, session.CongestionState())
+	require.EqualValues(t, 95, session.RateLimitStats().CongestionState)
+	require.EqualValues(t, 25, session.RateLimitStats().Rate)
+
+	// relieved
+	submit(10)
+	require.EqualValues(t, 100, session.RateLimitStats().Rate)
+}
+
+func TestSessionEncodings(t *testing.T) {
+	server, addr := newTestServer(t, ServerSettings{
+		SessionSettings: func(*ServerSession) Settings {
+			return Settings{ReadTimeout: 2 * time.Second}
+		},
+	})
+	defer func() {
+		_ = server.Close()
+	}()
+
+	received := make(chan string, 1)
+	session, err := NewSession(
+		TRXConnector(NonTLSDialer, Auth{SMSC: addr, SystemID: "esme", Password: "secret"}),
+		Settings{
+			ReadTimeout: 2 * time.Second,
+			Encodings:   data.Encodings{data.GSM7BITCoding: data.LATIN1},
+			OnPDU: func(p pdu.PDU, _ bool) {
+				if pd, ok := p.(*pdu.DeliverSM); ok {
+					text, _ := pd.Message.GetMessage()
+					received <- text
+				}
+			},
+		}, -1)
+	require.Nil(t, err)
+	defer func() {
+		_ = session.Close()
+	}()
+
+	require.Eventually(t, func() bool {
+		return len(server.Sessions()) == 1
+	}, time.Second, 10*time.Millisecond)
+
+	// data_coding 0x00 carrying ISO-8859-1 octets
+	deliverSM := pdu.NewDeliverSM().(*pdu.DeliverSM)
+	require.Nil(t, deliverSM.Message.SetMessageDataWithEncoding([]byte("caf\xe9"), data.GSM7BIT))
+	require.Nil(t, server.Sessions()[0].Submit(deliverSM))
+
+	select {
+	case text := <-received:
+		require.Equal(t, "café", text)
+	case <-time.After(2 * time.Second):
+		t.Fatal("deliver_sm was not received")
+	}
+}
+
+func TestServerSessionTracking(t *testing.T) {
+	server, addr := newTestServer(t, ServerSettings{
+		SessionSettings: func(*ServerSession) Settings {
+			return Settings{ReadTimeout: 2 * time.Second}
+		},
+	})
+
+	t.Run("ClosedBeforeTracked", func(t *testing.T) {
+		session := &ServerSession{}
+		require.True(t, server.trackSession(session, false))
+		require.True(t, server.trackSession(session, true))
+		require.Empty(t, server.Sessions())
+	})
+
+	t.Run("ClosedRightAfterBind", func(t *testing.T) {
+		for i := 0; i < 20; i++ {
+			conn, err := NonTLSDialer(addr)
+			require.Nil(t, err)
+
+			c := NewConnection(conn)
+			_, err = c.WritePDU(pdu.NewBindRequest(pdu.Transceiver))
+			require.Nil(t, err)
+
+			p, err := pdu.Parse(c)
+			require.Nil(t, err)
+			require.IsType(t, &pdu.BindResp{}, p)
+			_ = c.Close()
+		}
+
+		require.Eventually(t, func() bool {
+			return len(server.Sessions()) == 0
+		}, time.Second, 10*time.Millisecond)
+	})
+
+	t.Run("ServerClosed", func(t *testing.T) {
+		require.Nil(t, server.Close())
+		require.False(t, server.trackSession(&ServerSession{}, true))
+		require.Empty(t, server.Sessions())
+	})
+}
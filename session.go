@@ -1,9 +1,11 @@
 package gosmpp
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"github.com/linxGnu/gosmpp/pdu"
+	"sync"
 	"sync/atomic"
 	"time"
 )
@@ -23,12 +25,19 @@ type Session struct {
 	settings         Settings
 
 	rebindingInterval time.Duration
+	backoff           BackoffPolicy
 
 	trx atomic.Value // transceivable
 
 	state        int32
 	rebinding    int32
 	requestStore RequestStore
+
+	done chan struct{}
+
+	stateMu     sync.Mutex
+	lifecycle   SessionState
+	subscribers map[chan SessionStateChange]struct{}
 }
 
 type SessionOption func(session *Session)
@@ -41,72 +50,112 @@ type SessionOption func(session *Session)
 // `rebindingInterval` indicates duration that Session has to wait before rebinding again.
 //
 // Setting `rebindingInterval <= 0` will disable `auto-rebind` functionality.
+//
+// Session rebinds non-stop with constant `rebindingInterval` between attempts, unless a BackoffPolicy
+// is set by WithBackoffPolicy.
 func NewSession(c Connector, settings Settings, rebindingInterval time.Duration, opts ...SessionOption) (session *Session, err error) {
 	// Loop through each option
 
-	if settings.ReadTimeout <= 0 || settings.ReadTimeout <= settings.EnquireLink {
-		return nil, fmt.Errorf("invalid settings: ReadTimeout must greater than max(0, EnquireLink)")
+	if err = validateSettings(settings); err != nil {
+		return nil, err
 	}
 	var requestStore RequestStore = nil
 	if settings.WindowedRequestTracking != nil {
 		requestStore = NewDefaultStore()
-		if settings.MaxWindowSize == 0 {
-			return nil, ErrWindowSizeEqualZero
-		}
-		if settings.StoreAccessTimeOut == 0 {
-			return nil, ErrStoreAccessTimeOutEqualZero
-		}
-		if settings.PduExpireTimeOut > 0 && settings.ExpireCheckTimer == 0 {
-			return nil, ErrExpireCheckTimerNotSet
-		}
 	}
 
-	conn, err := c.Connect()
-	if err == nil {
-		session = &Session{
-			c:                 c,
-			rebindingInterval: rebindingInterval,
-			originalOnClosed:  settings.OnClosed,
-			requestStore:      requestStore,
-		}
+	session = &Session{
+		c:                 c,
+		rebindingInterval: rebindingInterval,
+		originalOnClosed:  settings.OnClosed,
+		requestStore:      requestStore,
+		done:              make(chan struct{}),
+		subscribers:       make(map[chan SessionStateChange]struct{}),
+	}
 
-		for _, opt := range opts {
-			opt(session)
-		}
+	for _, opt := range opts {
+		opt(session)
+	}
 
-		if rebindingInterval > 0 {
-			newSettings := settings
-			newSettings.OnClosed = func(state State) {
-				switch state {
-				case ExplicitClosing:
-					return
+	newSettings := settings
+	if rebindingInterval > 0 {
+		newSettings.OnClosed = func(state State) {
+			switch state {
+			case ExplicitClosing:
+				return
 
-				default:
-					if session.originalOnClosed != nil {
-						session.originalOnClosed(state)
-					}
-					session.rebind()
+			default:
+				if session.originalOnClosed != nil {
+					session.originalOnClosed(state)
 				}
+				session.rebind()
+			}
+		}
+	} else {
+		newSettings.OnClosed = func(state State) {
+			// bind is never restored without auto-rebind
+			session.setState(SessionClosed)
+			if session.originalOnClosed != nil {
+				session.originalOnClosed(state)
 			}
-			session.settings = newSettings
-		} else {
-			session.settings = settings
 		}
+	}
+	session.settings = newSettings
 
-		// bind to session
-		trans := newTransceivable(conn, session.settings, session.requestStore)
-		trans.start()
-		session.trx.Store(trans)
+	conn, err := session.connect()
+	if err != nil {
+		return nil, err
+	}
+
+	// bind to session
+	trans := newTransceivable(conn, session.settings, session.requestStore)
+	trans.start()
+	session.trx.Store(trans)
+	session.setState(SessionBound)
+
+	if f, ok := c.(failbackConnector); ok && rebindingInterval > 0 && f.failbackInterval() > 0 {
+		go session.failback(f)
 	}
 	return
 }
 
+func validateSettings(settings Settings) error {
+	if settings.ReadTimeout <= 0 || settings.ReadTimeout <= settings.EnquireLink {
+		return fmt.Errorf("invalid settings: ReadTimeout must greater than max(0, EnquireLink)")
+	}
+	if settings.RateLimit != nil && settings.RateLimit.Rate <= 0 {
+		return ErrInvalidRateLimit
+	}
+	if settings.WindowedRequestTracking != nil {
+		if settings.MaxWindowSize == 0 {
+			return ErrWindowSizeEqualZero
+		}
+		if settings.StoreAccessTimeOut == 0 {
+			return ErrStoreAccessTimeOutEqualZero
+		}
+		if settings.PduExpireTimeOut > 0 && settings.ExpireCheckTimer == 0 {
+			return ErrExpireCheckTimerNotSet
+		}
+	}
+	return nil
+}
+
 func WithRequestStore(store RequestStore) SessionOption {
 	return func(s *Session) {
 		s.requestStore = store
 	}
 }
 
+// WithBackoffPolicy replaces constant `rebindingInterval` between rebinding attempts with given policy.
+//
+// Session gives up rebinding when policy says so or bind is rejected with one of NonRetryableBindStatuses,
+// then it is closed and Settings.OnRebindingGiveUp is notified.
+func WithBackoffPolicy(policy BackoffPolicy) SessionOption {
+	return func(s *Session) {
+		s.backoff = policy
+	}
+}
+
 func (s *Session) bound() *transceivable {
 	r, _ := s.trx.Load().(*transceivable)
 	return r
@@ -127,6 +176,28 @@ func (s *Session) Transceiver() Transceiver {
 	return s.bound()
 }
 
+// SubmitContext submits a PDU through bound transceivable.
+//
+// If WindowedRequestTracking is configured and window is full, SubmitContext blocks
+// until a window slot is freed (a response comes or a request expires) or context is done,
+// instead of failing with ErrWindowsFull.
+func (s *Session) SubmitContext(ctx context.Context, p pdu.PDU) error {
+	return s.bound().SubmitContext(ctx, p)
+}
+
+// SubmitAsync submits a PDU through bound transceivable and returns a future of its response.
+func (s *Session) SubmitAsync(p pdu.PDU) (*ResponseFuture, error) {
+	return s.bound().SubmitAsync(p)
+}
+
+// SubmitAndWait submits a PDU through bound transceivable and waits for its response
+// (i.e. SubmitSMResp or GenericNack) until context is done.
+//
+// Response is correlated with the request by sequence number.
+func (s *Session) SubmitAndWait(ctx context.Context, p pdu.PDU) (pdu.PDU, error) {
+	return s.bound().SubmitAndWait(ctx, p)
+}
+
 func (s *Session) GetWindowSize() (int, error) {
 	if s.c.GetBindType() == pdu.Transmitter || s.c.GetBindType() == pdu.Transceiver {
 		size, err := s.bound().GetWindowSize()
@@ -138,10 +209,127 @@ func (s *Session) GetWindowSize() (int, error) {
 	return 0, ErrWindowSizeNotAvailableOnReceiverBinds
 }
 
+// RateLimitStats returns statistics of outbound rate limiter of current bind.
+func (s *Session) RateLimitStats() RateLimitStats {
+	return s.bound().RateLimitStats()
+}
+
+// Capabilities returns features supported within current bind, according to interface version
+// negotiated with SMSC. See WithInterfaceVersion.
+func (s *Session) Capabilities() Capabilities {
+	return newCapabilities(s.bound().InterfaceVersion())
+}
+
+// Endpoint returns SMSC address of current bind, e.g. one chosen by FailoverConnector.
+func (s *Session) Endpoint() string {
+	return s.bound().Endpoint()
+}
+
+// CongestionState returns the latest congestion_state (0-100) reported by SMSC within current bind,
+// as defined by SMPP 5.0. Zero indicates idle SMSC or no report.
+func (s *Session) CongestionState() byte {
+	return s.bound().CongestionState()
+}
+
+// State returns lifecycle state of session.
+func (s *Session) State() SessionState {
+	s.stateMu.Lock()
+	defer s.stateMu.Unlock()
+	return s.lifecycle
+}
+
+// SubscribeState returns a channel receiving lifecycle state changes of session, with given buffer size,
+// and a function to unsubscribe.
+//
+// Changes are dropped when channel buffer is full, to never block session. Channel is closed
+// once session is closed or unsubscribed.
+func (s *Session) SubscribeState(buffer int) (<-chan SessionStateChange, func()) {
+	ch := make(chan SessionStateChange, buffer)
+
+	s.stateMu.Lock()
+	if s.lifecycle == SessionClosed {
+		close(ch)
+	} else {
+		s.subscribers[ch] = struct{}{}
+	}
+	s.stateMu.Unlock()
+
+	return ch, func() {
+		s.stateMu.Lock()
+		if _, ok := s.subscribers[ch]; ok {
+			delete(s.subscribers, ch)
+			close(ch)
+		}
+		s.stateMu.Unlock()
+	}
+}
+
+// LastTraffic returns time of the latest PDU received from SMSC within current bind, or bind time if none.
+func (s *Session) LastTraffic() time.Time {
+	return s.bound().LastTraffic()
+}
+
+// LastEnquireLinkResp returns time of the latest successful enquire_link_resp within current bind,
+// zero if none.
+func (s *Session) LastEnquireLinkResp() time.Time {
+	return s.bound().LastEnquireLinkResp()
+}
+
+// Healthy checks if session is bound and SMSC is responsive, i.e. a PDU was received from SMSC within
+// ReadTimeout, e.g. for readiness probes.
+//
+// With EnquireLink set, an idle but alive bind keeps receiving enquire_link_resp.
+func (s *Session) Healthy() bool {
+	return s.State() == SessionBound && time.Since(s.LastTraffic()) < s.settings.ReadTimeout
+}
+
+func (s *Session) setState(to SessionState) {
+	s.stateMu.Lock()
+	defer s.stateMu.Unlock()
+
+	from := s.lifecycle
+	if from == to || from == SessionClosed {
+		return
+	}
+	s.lifecycle = to
+
+	change := SessionStateChange{From: from, To: to, At: time.Now()}
+	for ch := range s.subscribers {
+		select {
+		case ch <- change:
+		default:
+		}
+
+		if to == SessionClosed {
+			delete(s.subscribers, ch)
+			close(ch)
+		}
+	}
+}
+
+// connect binds through connector, tracking lifecycle state.
+func (s *Session) connect() (*Connection, error) {
+	s.setState(SessionConnecting)
+	if c, ok := s.c.(stagedConnector); ok {
+		return c.connect(func() {
+			s.setState(SessionBinding)
+		})
+	}
+	return s.c.Connect()
+}
+
+// available checks if session is bound and could submit PDU(s).
+func (s *Session) available() bool {
+	return s.State() == SessionBound
+}
+
 // Close session.
 func (s *Session) Close() (err error) {
 	if atomic.CompareAndSwapInt32(&s.state, Alive, Closed) {
+		close(s.done)
+		s.setState(SessionUnbinding)
 		err = s.close()
+		s.setState(SessionClosed)
 	}
 	return
 }
@@ -155,20 +343,34 @@ func (s *Session) close() (err error) {
 
 func (s *Session) rebind() {
 	if atomic.CompareAndSwapInt32(&s.rebinding, 0, 1) {
+		s.setState(SessionRebinding)
 		_ = s.close()
 
-		for atomic.LoadInt32(&s.state) == Alive {
-			conn, err := s.c.Connect()
+		start := time.Now()
+		for attempt := 1; atomic.LoadInt32(&s.state) == Alive; attempt++ {
+			conn, err := s.connect()
 			if err != nil {
+				s.setState(SessionRebinding)
 				if s.settings.OnRebindingError != nil {
 					s.settings.OnRebindingError(err)
 				}
-				time.Sleep(s.rebindingInterval)
+
+				wait, ok := s.nextRebinding(attempt, time.Since(start), err)
+				if !ok {
+					s.giveUp(err)
+					return
+				}
+
+				select {
+				case <-s.done:
+				case <-time.After(wait):
+				}
 			} else {
 				// bind to session
 				trans := newTransceivable(conn, s.settings, s.requestStore)
 				trans.start()
 				s.trx.Store(trans)
+				s.setState(SessionBound)
 
 				// reset rebinding state
 				atomic.StoreInt32(&s.rebinding, 0)
@@ -181,3 +383,43 @@ func (s *Session) rebind() {
 		}
 	}
 }
+
+// nextRebinding returns waiting duration before next rebinding attempt, false to give up.
+func (s *Session) nextRebinding(attempt int, elapsed time.Duration, err error) (time.Duration, bool) {
+	if s.backoff == nil {
+		return s.rebindingInterval, true
+	}
+	if !IsRetryableBindError(err) {
+		return 0, false
+	}
+	return s.backoff.Next(attempt, elapsed)
+}
+
+// giveUp closes session after rebinding failed for good.
+func (s *Session) giveUp(err error) {
+	if atomic.CompareAndSwapInt32(&s.state, Alive, Closed) {
+		close(s.done)
+		s.setState(SessionClosed)
+		if s.settings.OnRebindingGiveUp != nil {
+			s.settings.OnRebindingGiveUp(err)
+		}
+	}
+}
+
+// failback rebinds session once a more preferred endpoint of connector is reachable again.
+func (s *Session) failback(f failbackConnector) {
+	ticker := time.NewTicker(f.failbackInterval())
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.done:
+			return
+
+		case <-ticker.C:
+			if s.State() == SessionBound && f.failback() {
+				s.rebind()
+			}
+		}
+	}
+}
@@ -112,3 +112,71 @@ func TestGetWindowSize(t *testing.T) {
 	err = s.Close()
 	require.Nil(t, err)
 }
+
+func TestSessionLifecycle(t *testing.T) {
+	server, addr := newTestServer(t, ServerSettings{
+		SessionSettings: func(*ServerSession) Settings {
+			return Settings{ReadTimeout: 2 * time.Second}
+		},
+	})
+	defer func() {
+		_ = server.Close()
+	}()
+
+	s, err := NewSession(
+		TRXConnector(NonTLSDialer, Auth{SMSC: addr, SystemID: "esme", Password: "secret"}),
+		Settings{
+			EnquireLink: 50 * time.Millisecond,
+			ReadTimeout: time.Second,
+		}, 10*time.Millisecond)
+	require.Nil(t, err)
+	require.Equal(t, SessionBound, s.State())
+
+	require.Eventually(t, func() bool {
+		return !s.LastEnquireLinkResp().IsZero()
+	}, time.Second, 10*time.Millisecond)
+	require.True(t, s.Healthy())
+	require.False(t, s.LastTraffic().Before(s.LastEnquireLinkResp()))
+
+	changes, _ := s.SubscribeState(16)
+	expect := func(from, to SessionState) {
+		select {
+		case change := <-changes:
+			require.Equal(t, from, change.From)
+			require.Equal(t, to, change.To)
+			require.False(t, change.At.IsZero())
+		case <-time.After(2 * time.Second):
+			t.Fatalf("missing change from %s to %s", from, to)
+		}
+	}
+
+	// SMSC drops the bind
+	require.Eventually(t, func() bool {
+		return len(server.Sessions()) == 1
+	}, time.Second, 10*time.Millisecond)
+	require.Nil(t, server.Sessions()[0].Close())
+
+	expect(SessionBound, SessionRebinding)
+	expect(SessionRebinding, SessionConnecting)
+	expect(SessionConnecting, SessionBinding)
+	expect(SessionBinding, SessionBound)
+	require.Equal(t, SessionBound, s.State())
+
+	unsubscribedChanges, unsubscribe := s.SubscribeState(1)
+	unsubscribe()
+	_, ok := <-unsubscribedChanges
+	require.False(t, ok)
+
+	require.Nil(t, s.Close())
+	expect(SessionBound, SessionUnbinding)
+	expect(SessionUnbinding, SessionClosed)
+	_, ok = <-changes
+	require.False(t, ok)
+
+	require.Equal(t, SessionClosed, s.State())
+	require.False(t, s.Healthy())
+
+	closedChanges, _ := s.SubscribeState(1)
+	_, ok = <-closedChanges
+	require.False(t, ok)
+}
@@ -1,5 +1,7 @@
 package gosmpp
 
+import "time"
+
 const (
 	Alive int32 = iota
 	Closed
@@ -53,3 +55,59 @@ func (s *State) String() string {
 		return ""
 	}
 }
+
+// SessionState represents lifecycle state of Session.
+type SessionState byte
+
+const (
+	// SessionConnecting indicates that Session is establishing network connection to SMSC.
+	SessionConnecting SessionState = iota
+
+	// SessionBinding indicates that Session sent bind request and waits for its response.
+	SessionBinding
+
+	// SessionBound indicates that Session is bound and could exchange PDU(s) with SMSC.
+	SessionBound
+
+	// SessionUnbinding indicates that Session is being closed explicitly.
+	SessionUnbinding
+
+	// SessionRebinding indicates that bind of Session was lost and Session waits before rebinding.
+	SessionRebinding
+
+	// SessionClosed indicates that Session is closed and never rebinds.
+	SessionClosed
+)
+
+// String interface.
+func (s SessionState) String() string {
+	switch s {
+	case SessionConnecting:
+		return "Connecting"
+
+	case SessionBinding:
+		return "Binding"
+
+	case SessionBound:
+		return "Bound"
+
+	case SessionUnbinding:
+		return "Unbinding"
+
+	case SessionRebinding:
+		return "Rebinding"
+
+	case SessionClosed:
+		return "Closed"
+
+	default:
+		return ""
+	}
+}
+
+// SessionStateChange represents a transition of Session lifecycle state.
+type SessionStateChange struct {
+	From SessionState
+	To   SessionState
+	At   time.Time
+}
@@ -43,3 +43,17 @@ func TestState_String(t *testing.T) {
 		})
 	}
 }
+
+func TestSessionState_String(t *testing.T) {
+	for s, want := range map[SessionState]string{
+		SessionConnecting: "Connecting",
+		SessionBinding:    "Binding",
+		SessionBound:      "Bound",
+		SessionUnbinding:  "Unbinding",
+		SessionRebinding:  "Rebinding",
+		SessionClosed:     "Closed",
+		SessionState(100): "",
+	} {
+		assert.Equalf(t, want, s.String(), "String()")
+	}
+}
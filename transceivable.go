@@ -11,6 +11,9 @@ import (
 
 var (
 	ErrWindowNotConfigured = errors.New("window settings not configured")
+
+	// ErrPDUNotRespondable indicates that submitted PDU does not expect any response.
+	ErrPDUNotRespondable = errors.New("PDU does not expect response")
 )
 
 type transceivable struct {
@@ -23,8 +26,17 @@ type transceivable struct {
 	in     *receivable
 	out    *transmittable
 
-	aliveState   int32
-	requestStore RequestStore
+	aliveState      int32
+	congestionState int32
+	requestStore    RequestStore
+	pending         *pendingResponses
+
+	// unix nano timestamps of the latest received PDU and successful enquire_link_resp
+	lastTraffic         int64
+	lastEnquireLinkResp int64
+
+	retryMu  sync.Mutex
+	retrying map[pdu.PDU]*time.Timer
 }
 type TransceivableOption func(session *Session)
 
@@ -34,6 +46,9 @@ func newTransceivable(conn *Connection, settings Settings, requestStore RequestS
 		settings:     settings,
 		conn:         conn,
 		requestStore: requestStore,
+		pending:      newPendingResponses(),
+		retrying:     make(map[pdu.PDU]*time.Timer),
+		lastTraffic:  time.Now().UnixNano(),
 	}
 	t.ctx, t.cancel = context.WithCancel(context.Background())
 
@@ -42,13 +57,25 @@ func newTransceivable(conn *Connection, settings Settings, requestStore RequestS
 
 		EnquireLink: settings.EnquireLink,
 
-		OnSubmitError: settings.OnSubmitError,
+		RateLimit: settings.RateLimit,
+
+		onSequenceAssigned: t.pending.sent,
+
+		OnSubmitError: func(p pdu.PDU, err error) {
+			t.pending.fail(p, err)
+
+			if t.settings.OnSubmitError != nil {
+				t.settings.OnSubmitError(p, err)
+			}
+		},
 
 		OnClosed: func(state State) {
 			switch state {
 			case ConnectionIssue:
 				// also close input
 				_ = t.in.close(ExplicitClosing)
+				t.pending.failAll(ErrConnectionClosing)
+				t.stopRetrying()
 
 				if t.settings.OnClosed != nil {
 					t.settings.OnClosed(ConnectionIssue)
@@ -70,11 +97,15 @@ func newTransceivable(conn *Connection, settings Settings, requestStore RequestS
 
 		OnReceivingError: settings.OnReceivingError,
 
+		Encodings: settings.Encodings,
+
 		OnClosed: func(state State) {
 			switch state {
 			case InvalidStreaming, UnbindClosing:
 				// also close output
 				_ = t.out.close(ExplicitClosing)
+				t.pending.failAll(ErrConnectionClosing)
+				t.stopRetrying()
 
 				if t.settings.OnClosed != nil {
 					t.settings.OnClosed(state)
@@ -89,6 +120,18 @@ func newTransceivable(conn *Connection, settings Settings, requestStore RequestS
 		response: func(p pdu.PDU) {
 			_ = t.Submit(p)
 		},
+
+		onResponse: t.resolve,
+
+		onCongestionState: t.congest,
+
+		onReceived: t.received,
+
+		retry: t.retry,
+
+		onWindowFreed: func() {
+			t.out.notifyWindowFreed()
+		},
 	},
 		requestStore,
 	)
@@ -113,6 +156,51 @@ func (t *transceivable) SystemID() string {
 	return t.conn.systemID
 }
 
+// InterfaceVersion returns interface version negotiated within bind.
+func (t *transceivable) InterfaceVersion() byte {
+	return t.conn.interfaceVersion
+}
+
+// Endpoint returns SMSC address dialed for bind.
+func (t *transceivable) Endpoint() string {
+	return t.conn.endpoint
+}
+
+// CongestionState returns the latest congestion_state reported by SMSC.
+func (t *transceivable) CongestionState() byte {
+	return byte(atomic.LoadInt32(&t.congestionState))
+}
+
+// LastTraffic returns time of the latest PDU received from SMSC, or bind time if none.
+func (t *transceivable) LastTraffic() time.Time {
+	return time.Unix(0, atomic.LoadInt64(&t.lastTraffic))
+}
+
+// LastEnquireLinkResp returns time of the latest successful enquire_link_resp, zero if none.
+func (t *transceivable) LastEnquireLinkResp() (last time.Time) {
+	if v := atomic.LoadInt64(&t.lastEnquireLinkResp); v != 0 {
+		last = time.Unix(0, v)
+	}
+	return
+}
+
+// received tracks activity of bind.
+func (t *transceivable) received(p pdu.PDU) {
+	now := time.Now().UnixNano()
+	atomic.StoreInt64(&t.lastTraffic, now)
+	if _, ok := p.(*pdu.EnquireLinkResp); ok && p.IsOk() {
+		atomic.StoreInt64(&t.lastEnquireLinkResp, now)
+	}
+}
+
+// congest adapts send rate to congestion_state reported by SMSC.
+func (t *transceivable) congest(state byte) {
+	atomic.StoreInt32(&t.congestionState, int32(state))
+	if t.out.limiter != nil {
+		t.out.limiter.congest(state)
+	}
+}
+
 // Close transceiver and stop underlying daemons.
 func (t *transceivable) Close() (err error) {
 	return t.closing(ExplicitClosing)
@@ -123,6 +211,42 @@ func (t *transceivable) Submit(p pdu.PDU) error {
 	return t.out.Submit(p)
 }
 
+// SubmitContext submits a PDU, blocking while request window is full
+// until a window slot is freed or context is done.
+func (t *transceivable) SubmitContext(ctx context.Context, p pdu.PDU) error {
+	return t.out.SubmitContext(ctx, p)
+}
+
+// SubmitAsync submits a PDU and returns a future of its response.
+func (t *transceivable) SubmitAsync(p pdu.PDU) (f *ResponseFuture, err error) {
+	if !p.CanResponse() {
+		return nil, ErrPDUNotRespondable
+	}
+
+	f = t.pending.add(p)
+	if err = t.Submit(p); err != nil {
+		t.pending.fail(p, err)
+		f = nil
+	}
+	return
+}
+
+// SubmitAndWait submits a PDU and waits for its response until context is done.
+//
+// Like SubmitContext, it blocks while request window is full.
+func (t *transceivable) SubmitAndWait(ctx context.Context, p pdu.PDU) (pdu.PDU, error) {
+	if !p.CanResponse() {
+		return nil, ErrPDUNotRespondable
+	}
+
+	f := t.pending.add(p)
+	if err := t.SubmitContext(ctx, p); err != nil {
+		t.pending.fail(p, err)
+		return nil, err
+	}
+	return f.Wait(ctx)
+}
+
 func (t *transceivable) GetWindowSize() (int, error) {
 	if t.settings.WindowedRequestTracking != nil {
 		ctx, cancelFunc := context.WithTimeout(context.Background(), t.settings.StoreAccessTimeOut)
@@ -133,6 +257,93 @@ func (t *transceivable) GetWindowSize() (int, error) {
 
 }
 
+// outstanding returns the number of requests waiting for responses, i.e. size of request window
+// if configured, otherwise the number of futures returned by SubmitAsync and SubmitAndWait.
+func (t *transceivable) outstanding() int {
+	if size, err := t.GetWindowSize(); err == nil {
+		return size
+	}
+	return t.pending.len()
+}
+
+// RateLimitStats returns statistics of outbound rate limiter.
+func (t *transceivable) RateLimitStats() (stats RateLimitStats) {
+	if t.out.limiter != nil {
+		stats = t.out.limiter.Stats()
+	}
+	return
+}
+
+// retry schedules re-submitting of request if its response is retryable.
+func (t *transceivable) retry(request Request, resp pdu.PDU) bool {
+	if t.settings.WindowedRequestTracking == nil || t.settings.RetryPolicy == nil {
+		return false
+	}
+
+	policy := t.settings.RetryPolicy
+	if !policy.shouldRetry(request, resp) {
+		return false
+	}
+
+	p, retries := request.PDU, request.Retries+1
+
+	t.retryMu.Lock()
+	defer t.retryMu.Unlock()
+
+	if t.retrying == nil {
+		return false // stopped
+	}
+
+	t.retrying[p] = time.AfterFunc(policy.Backoff(request.Retries), func() {
+		t.retryMu.Lock()
+		_, ok := t.retrying[p]
+		delete(t.retrying, p)
+		t.retryMu.Unlock()
+
+		if !ok {
+			return
+		}
+
+		if err := t.out.resubmit(p, retries); err != nil && t.settings.OnSubmitError != nil {
+			t.settings.OnSubmitError(p, err)
+		}
+	})
+
+	return true
+}
+
+// resolve completes future waiting for given response. If RetryPolicy re-submits the request
+// instead, future keeps waiting for response of the re-submitted one.
+func (t *transceivable) resolve(resp pdu.PDU) bool {
+	if t.settings.WindowedRequestTracking != nil && t.settings.RetryPolicy != nil {
+		ctx, cancelFunc := context.WithTimeout(context.Background(), t.settings.StoreAccessTimeOut)
+		request, ok := t.requestStore.Get(ctx, resp.GetSequenceNumber())
+		cancelFunc()
+
+		if ok && t.settings.RetryPolicy.shouldRetry(request, resp) && t.pending.requeue(resp) {
+			if !t.retry(request, resp) {
+				t.pending.fail(request.PDU, ErrConnectionClosing)
+			}
+			return true
+		}
+	}
+	return t.pending.resolve(resp)
+}
+
+// stopRetrying cancels scheduled retries, notifying their requests as closed ones.
+func (t *transceivable) stopRetrying() {
+	t.retryMu.Lock()
+	retrying := t.retrying
+	t.retrying = nil
+	t.retryMu.Unlock()
+
+	for p, timer := range retrying {
+		if timer.Stop() && t.settings.OnClosePduRequest != nil {
+			t.settings.OnClosePduRequest(p)
+		}
+	}
+}
+
 func (t *transceivable) windowCleanup() {
 	ticker := time.NewTicker(t.settings.ExpireCheckTimer)
 	defer ticker.Stop()
@@ -145,6 +356,7 @@ func (t *transceivable) windowCleanup() {
 			for _, request := range t.requestStore.List(ctx) {
 				if time.Since(request.TimeSent) > t.settings.PduExpireTimeOut {
 					_ = t.requestStore.Delete(ctx, request.GetSequenceNumber())
+					t.out.notifyWindowFreed()
 					if t.settings.OnExpiredPduRequest != nil {
 						if t.settings.OnExpiredPduRequest(request.PDU) {
 							_ = t.closing(ConnectionIssue)
@@ -168,6 +380,10 @@ func (t *transceivable) closing(state State) (err error) {
 		// close underlying conn
 		err = t.conn.Close()
 
+		// release awaiting submitters
+		t.pending.failAll(ErrConnectionClosing)
+		t.stopRetrying()
+
 		// notify transceiver closed
 		if t.settings.OnClosed != nil {
 			t.settings.OnClosed(state)
@@ -29,6 +29,33 @@ type transmittable struct {
 	aliveState   int32
 	pendingWrite int32
 	requestStore RequestStore
+	limiter      *rateLimiter
+
+	// done is closed on closing, to release submitters waiting for rate limiter
+	done chan struct{}
+
+	// window slots reserved by SubmitContext, not yet written
+	windowMu       sync.Mutex
+	windowReserved int
+	windowFreed    chan struct{}
+}
+
+// queuedPDU is a PDU queued along with submission metadata.
+type queuedPDU struct {
+	pdu.PDU
+
+	// reserved indicates PDU holds a reserved window slot
+	reserved bool
+
+	// retries is the number of times PDU has been re-submitted by RetryPolicy
+	retries int
+}
+
+func unwrapPDU(p pdu.PDU) pdu.PDU {
+	if q, ok := p.(*queuedPDU); ok {
+		return q.PDU
+	}
+	return p
 }
 
 func newTransmittable(conn *Connection, settings Settings, requestStore RequestStore) *transmittable {
@@ -39,6 +66,12 @@ func newTransmittable(conn *Connection, settings Settings, requestStore RequestS
 		aliveState:   Alive,
 		pendingWrite: 0,
 		requestStore: requestStore,
+		windowFreed:  make(chan struct{}),
+		done:         make(chan struct{}),
+	}
+
+	if settings.RateLimit != nil {
+		t.limiter = newRateLimiter(settings.RateLimit)
 	}
 
 	return t
@@ -46,10 +79,15 @@ func newTransmittable(conn *Connection, settings Settings, requestStore RequestS
 
 func (t *transmittable) close(state State) (err error) {
 	if atomic.CompareAndSwapInt32(&t.aliveState, Alive, Closed) {
+		close(t.done)
+
 		for atomic.LoadInt32(&t.pendingWrite) != 0 {
 			runtime.Gosched()
 		}
 
+		// wake up submitters waiting for window slot
+		t.notifyWindowFreed()
+
 		// notify daemon
 		close(t.input)
 
@@ -104,6 +142,19 @@ func (t *transmittable) closing(state State) {
 
 // Submit a PDU.
 func (t *transmittable) Submit(p pdu.PDU) (err error) {
+	return t.submit(p)
+}
+
+// resubmit a PDU which is retried by RetryPolicy.
+func (t *transmittable) resubmit(p pdu.PDU, retries int) (err error) {
+	return t.submit(&queuedPDU{PDU: p, retries: retries})
+}
+
+func (t *transmittable) submit(p pdu.PDU) (err error) {
+	if err = t.throttle(context.Background(), p); err != nil {
+		return
+	}
+
 	atomic.AddInt32(&t.pendingWrite, 1)
 
 	if atomic.LoadInt32(&t.aliveState) == Alive {
@@ -116,6 +167,107 @@ func (t *transmittable) Submit(p pdu.PDU) (err error) {
 	return
 }
 
+// SubmitContext submits a PDU.
+//
+// If request window is configured and full, SubmitContext blocks until a window slot
+// is freed (response comes or request expires) or context is done.
+//
+// Mixing with Submit is possible, but PDU(s) from Submit could take freed slots first,
+// causing ErrWindowsFull for PDU(s) from SubmitContext.
+func (t *transmittable) SubmitContext(ctx context.Context, p pdu.PDU) (err error) {
+	if t.isWindowed(p) {
+		if err = t.reserveWindow(ctx); err != nil {
+			return
+		}
+		p = &queuedPDU{PDU: p, reserved: true}
+	}
+
+	if err = t.throttle(ctx, p); err != nil {
+		if q, ok := p.(*queuedPDU); ok && q.reserved {
+			t.releaseWindow()
+		}
+		return
+	}
+
+	atomic.AddInt32(&t.pendingWrite, 1)
+
+	if atomic.LoadInt32(&t.aliveState) == Alive {
+		select {
+		case t.input <- p:
+		case <-ctx.Done():
+			err = ctx.Err()
+		}
+	} else {
+		err = ErrConnectionClosing
+	}
+
+	atomic.AddInt32(&t.pendingWrite, -1)
+
+	if q, ok := p.(*queuedPDU); ok && q.reserved && err != nil {
+		t.releaseWindow()
+	}
+	return
+}
+
+func (t *transmittable) isWindowed(p pdu.PDU) bool {
+	return t.settings.WindowedRequestTracking != nil && t.settings.MaxWindowSize > 0 && isAllowPDU(p)
+}
+
+// reserveWindow waits for a free slot in request window and reserves it.
+func (t *transmittable) reserveWindow(ctx context.Context) error {
+	for {
+		t.windowMu.Lock()
+
+		if atomic.LoadInt32(&t.aliveState) != Alive {
+			t.windowMu.Unlock()
+			return ErrConnectionClosing
+		}
+
+		freed := t.windowFreed
+
+		storeCtx, cancelFunc := context.WithTimeout(ctx, t.settings.StoreAccessTimeOut)
+		length, err := t.requestStore.Length(storeCtx)
+		cancelFunc()
+		if err != nil {
+			t.windowMu.Unlock()
+			return err
+		}
+
+		if length+t.windowReserved < int(t.settings.MaxWindowSize) {
+			t.windowReserved++
+			t.windowMu.Unlock()
+			return nil
+		}
+
+		t.windowMu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-freed:
+		}
+	}
+}
+
+// releaseWindow releases a reserved window slot.
+func (t *transmittable) releaseWindow() {
+	t.windowMu.Lock()
+	t.windowReserved--
+	t.windowMu.Unlock()
+
+	t.notifyWindowFreed()
+}
+
+// notifyWindowFreed wakes up all submitters waiting for window slot.
+func (t *transmittable) notifyWindowFreed() {
+	t.windowMu.Lock()
+	if t.windowFreed != nil {
+		close(t.windowFreed)
+	}
+	t.windowFreed = make(chan struct{})
+	t.windowMu.Unlock()
+}
+
 func (t *transmittable) start() {
 	t.wg.Add(1)
 	if t.settings.EnquireLink > 0 {
@@ -132,7 +284,10 @@ func (t *transmittable) start() {
 }
 
 func (t *transmittable) drain() {
-	for range t.input {
+	for p := range t.input {
+		if q, ok := p.(*queuedPDU); ok && q.reserved {
+			t.releaseWindow()
+		}
 	}
 }
 
@@ -180,12 +335,23 @@ func (t *transmittable) loopWithEnquireLink() {
 	}
 }
 
+// throttle waits for rate limiter if configured, before PDU is queued, so that
+// enquire_link and responses are never delayed behind throttled requests.
+func (t *transmittable) throttle(ctx context.Context, p pdu.PDU) error {
+	if t.limiter == nil {
+		return nil
+	}
+	return t.limiter.wait(ctx, t.done, unwrapPDU(p))
+}
+
 // check error and do closing if need
 func (t *transmittable) check(p pdu.PDU, n int, err error) (closing bool) {
 	if err == nil {
 		return
 	}
 
+	p = unwrapPDU(p)
+
 	if t.settings.OnSubmitError != nil {
 		t.settings.OnSubmitError(p, err)
 	}
@@ -211,6 +377,16 @@ func (t *transmittable) check(p pdu.PDU, n int, err error) (closing bool) {
 
 // low level writing
 func (t *transmittable) write(p pdu.PDU) (n int, err error) {
+	var retries int
+	if q, ok := p.(*queuedPDU); ok {
+		p, retries = q.PDU, q.retries
+
+		// reserved slot is either taken by stored request or given back
+		if q.reserved {
+			defer t.releaseWindow()
+		}
+	}
+
 	if t.settings.WriteTimeout > 0 {
 		err = t.conn.SetWriteTimeout(t.settings.WriteTimeout)
 	}
@@ -218,7 +394,7 @@ func (t *transmittable) write(p pdu.PDU) (n int, err error) {
 		return
 	}
 
-	if t.settings.WindowedRequestTracking != nil && t.settings.MaxWindowSize > 0 && isAllowPDU(p) {
+	if t.isWindowed(p) {
 		ctx, cancelFunc := context.WithTimeout(context.Background(), t.settings.StoreAccessTimeOut)
 		defer cancelFunc()
 		var length int
@@ -226,29 +402,55 @@ func (t *transmittable) write(p pdu.PDU) (n int, err error) {
 		if err != nil {
 			return 0, err
 		}
-		if length < int(t.settings.MaxWindowSize) {
-			n, err = t.conn.WritePDU(p)
-			if err != nil {
-				return 0, err
-			}
-			request := Request{
-				PDU:      p,
-				TimeSent: time.Now(),
-			}
-			err = t.requestStore.Set(ctx, request)
-			if err != nil {
-				return 0, err
-			}
-		} else {
+		if length >= int(t.settings.MaxWindowSize) {
 			return 0, ErrWindowsFull
 		}
+
+		if err = t.assignSequence(p); err != nil {
+			return 0, err
+		}
+		n, err = t.conn.WritePDU(p)
+		if err != nil {
+			return 0, err
+		}
+		request := Request{
+			PDU:      p,
+			TimeSent: time.Now(),
+			Retries:  retries,
+		}
+		err = t.requestStore.Set(ctx, request)
+		if err != nil {
+			return 0, err
+		}
 	} else {
+		if err = t.assignSequence(p); err != nil {
+			return 0, err
+		}
 		n, err = t.conn.WritePDU(p)
 	}
 
 	return
 }
 
+// assignSequence assigns sequence number to request about to be written,
+// so that sequence numbers are in order of writing.
+func (t *transmittable) assignSequence(p pdu.PDU) error {
+	if !isRequest(p) {
+		return nil
+	}
+
+	seq, err := t.conn.NextSequenceNumber()
+	if err != nil {
+		return err
+	}
+	p.SetSequenceNumber(seq)
+
+	if t.settings.onSequenceAssigned != nil {
+		t.settings.onSequenceAssigned(p)
+	}
+	return nil
+}
+
 func isAllowPDU(p pdu.PDU) bool {
 	if p.CanResponse() {
 		switch p.(type) {
@@ -1,6 +1,7 @@
 package gosmpp
 
 import (
+	"context"
 	"fmt"
 	"net"
 	"sync"
@@ -8,6 +9,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/linxGnu/gosmpp/data"
 	"github.com/linxGnu/gosmpp/pdu"
 
 	"github.com/stretchr/testify/require"
@@ -179,3 +181,140 @@ func TestConcurrentSubmitClose(t *testing.T) {
 
 	wg.Wait()
 }
+
+func TestSubmitContextWindowFull(t *testing.T) {
+	var maxWindow, responses int32
+
+	server, addr := newTestServer(t, ServerSettings{
+		SessionSettings: func(session *ServerSession) Settings {
+			return Settings{
+				ReadTimeout: 2 * time.Second,
+				OnAllPDU: func(p pdu.PDU) (pdu.PDU, bool) {
+					if pd, ok := p.(*pdu.SubmitSM); ok {
+						if msg, _ := pd.Message.GetMessage(); msg != "drop" {
+							go func() {
+								time.Sleep(30 * time.Millisecond)
+								_ = session.Submit(pd.GetResponse())
+							}()
+						}
+					}
+					return nil, false
+				},
+			}
+		},
+	})
+	defer func() {
+		_ = server.Close()
+	}()
+
+	var session *Session
+	session, err := NewSession(
+		TRXConnector(NonTLSDialer, Auth{SMSC: addr, SystemID: "esme"}),
+		Settings{
+			ReadTimeout: 2 * time.Second,
+			OnSubmitError: func(_ pdu.PDU, err error) {
+				t.Error(err)
+			},
+			WindowedRequestTracking: &WindowedRequestTracking{
+				MaxWindowSize:      2,
+				StoreAccessTimeOut: 100 * time.Millisecond,
+				OnExpectedPduResponse: func(Response) {
+					if size, _ := session.GetWindowSize(); int32(size) > atomic.LoadInt32(&maxWindow) {
+						atomic.StoreInt32(&maxWindow, int32(size))
+					}
+					atomic.AddInt32(&responses, 1)
+				},
+			},
+		}, -1)
+	require.Nil(t, err)
+	defer func() {
+		_ = session.Close()
+	}()
+
+	newSubmit := func(msg string) pdu.PDU {
+		p := pdu.NewSubmitSM().(*pdu.SubmitSM)
+		_ = p.Message.SetMessageWithEncoding(msg, data.GSM7BIT)
+		return p
+	}
+
+	for i := 0; i < 6; i++ {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		require.Nil(t, session.SubmitContext(ctx, newSubmit("hello")))
+		cancel()
+	}
+
+	require.Eventually(t, func() bool {
+		return atomic.LoadInt32(&responses) == 6
+	}, 2*time.Second, 10*time.Millisecond)
+	require.LessOrEqual(t, atomic.LoadInt32(&maxWindow), int32(2))
+
+	// fill up the window with requests which are never responded
+	for i := 0; i < 2; i++ {
+		require.Nil(t, session.SubmitContext(context.Background(), newSubmit("drop")))
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	require.ErrorIs(t, session.SubmitContext(ctx, newSubmit("hello")), context.DeadlineExceeded)
+}
+
+func TestWindowFullKeepsSequence(t *testing.T) {
+	server, addr := newTestServer(t, ServerSettings{
+		SessionSettings: func(*ServerSession) Settings {
+			return Settings{
+				ReadTimeout: 2 * time.Second,
+				OnAllPDU: func(pdu.PDU) (pdu.PDU, bool) {
+					return nil, false // never respond, keeping the window full
+				},
+			}
+		},
+	})
+	defer func() {
+		_ = server.Close()
+	}()
+
+	submitErrors := make(chan error, 1)
+
+	sequence := NewSequenceGenerator()
+	session, err := NewSession(
+		TRXConnector(NonTLSDialer, Auth{SMSC: addr}, WithSequenceGenerator(sequence)),
+		Settings{
+			ReadTimeout: 2 * time.Second,
+			OnSubmitError: func(_ pdu.PDU, err error) {
+				submitErrors <- err
+			},
+			WindowedRequestTracking: &WindowedRequestTracking{
+				MaxWindowSize:         1,
+				StoreAccessTimeOut:    100 * time.Millisecond,
+				OnExpectedPduResponse: func(Response) {},
+			},
+		}, -1)
+	require.Nil(t, err)
+	defer func() {
+		_ = session.Close()
+	}()
+
+	require.Nil(t, session.Transceiver().Submit(pdu.NewSubmitSM()))
+	require.Eventually(t, func() bool {
+		size, _ := session.GetWindowSize()
+		return size == 1
+	}, time.Second, 10*time.Millisecond)
+
+	p := pdu.NewSubmitSM()
+	p.SetSequenceNumber(0)
+	require.Nil(t, session.Transceiver().Submit(p))
+
+	select {
+	case err := <-submitErrors:
+		require.Equal(t, ErrWindowsFull, err)
+	case <-time.After(time.Second):
+		t.Fatal("window full error not notified")
+	}
+
+	// rejected request does not consume sequence number
+	require.Zero(t, p.GetSequenceNumber())
+
+	next, err := sequence.Next()
+	require.Nil(t, err)
+	require.EqualValues(t, 3, next) // bind_transceiver, then the first submit_sm
+}